@@ -1,7 +1,12 @@
 package sdbc
 
 import (
+	"bytes"
 	"fmt"
+	"reflect"
+	"slices"
+	"sync"
+	"time"
 
 	"github.com/fxamacker/cbor/v2"
 )
@@ -10,6 +15,14 @@ import (
 // SurrealDB spec: https://surrealdb.com/docs/surrealdb/integration/cbor
 
 const (
+	// cborTagDatetimeRFC3339 represents a DateTime as an RFC 3339 string.
+	// It is defined by the IANA specification (tag 0).
+	cborTagDatetimeRFC3339 = 0
+
+	// cborTagDatetimeEpoch represents a DateTime as a number (integer or float)
+	// of seconds since the unix epoch. It is defined by the IANA specification (tag 1).
+	cborTagDatetimeEpoch = 1
+
 	// CBORTagNone represents a NONE value.
 	// The value passed to the tagged value is null, as it cannot be empty.
 	CBORTagNone = 6
@@ -29,51 +42,414 @@ const (
 	// It is preferred by SurrealDB over the standard IANA tag 0.
 	cborTagDatetime = 12
 
+	// cborTagDurationString represents a Duration in the SurrealQL
+	// string format, e.g. "1h30m" or "1y2w3d4h5m6s7ms8us9ns".
+	// It is only supported for decoding, tag 14 is used for encoding.
+	cborTagDurationString = 13
+
 	// cborTagDuration represents a Duration as a two-value array, containing
 	// optionally seconds (number) and optionally nanoseconds (number).
 	// An empty array will be considered a Duration of 0.
 	// It is used instead of custom tag 13 (string representation).
 	cborTagDuration = 14
 
+	// cborTagFuture represents a Future as a string containing the SurrealQL block
+	// of the future, e.g. "{ time::now() }". Futures are only evaluated when selected.
+	cborTagFuture = 15
+
+	// cborTagUUIDString represents a UUID in string format.
+	// It is only supported for decoding, tag 37 is used for encoding.
+	cborTagUUIDString = 9
+
 	// CBORTagUUID represents a UUID in binary form.
 	// It is adopted from the IANA specification.
 	// It is preferred by SurrealDB over custom tag 9 (string).
-	//
-	// Please note: This const is exposed and not implemented by this package.
-	// This decision was made to minimize the number of dependencies.
-	// There is no UUID type in the standard library.
-	// As a third-party package, github.com/google/uuid is recommended.
 	CBORTagUUID = 37
 
-	// Custom Geometries:.
+	// cborTagRange represents a Range as a two-value array containing the
+	// lower and upper bound. Each bound is either null (unbounded) or
+	// a value tagged with one of the bound tags (50 or 51).
+	cborTagRange = 49
+
+	// cborTagBoundIncluded represents an inclusive bound of a Range.
+	cborTagBoundIncluded = 50
+
+	// cborTagBoundExcluded represents an exclusive bound of a Range.
+	cborTagBoundExcluded = 51
 
-	// A Geometry Point represented by a two-value array containing a lat (float) and lon (float).
-	// cborTagGeometryPoint = 88.
+	// cborTagFile represents a File as a two-value array
+	// containing a bucket (string) and a key (string).
+	cborTagFile = 55
 
-	// A Geometry Line represented by an array with two or more points (Tag 88).
-	// cborTagGeometryLine = 89.
+	// Custom Geometries:
 
-	// A Geometry Polygon represented by an array with one or more closed lines (Tag 89).
+	// cborTagGeometryPoint represents a Geometry Point as a
+	// two-value array containing a longitude (float) and latitude (float).
+	cborTagGeometryPoint = 88
+
+	// cborTagGeometryLine represents a Geometry Line as an array with two or more points (Tag 88).
+	cborTagGeometryLine = 89
+
+	// cborTagGeometryPolygon represents a Geometry Polygon as an array with one or more closed lines (Tag 89).
 	// If the lines are not closed, meaning that the first and last point are equal,
 	// then SurrealDB will automatically suffix the line with it's first point.
-	// cborTagGeometryPolygon = 90.
+	cborTagGeometryPolygon = 90
 
-	// A Geometry MultiPoint represented by an array with one or more points (Tag 88).
-	// cborTagGeometryMultiPoint = 91.
+	// cborTagGeometryMultiPoint represents a Geometry MultiPoint as an array with one or more points (Tag 88).
+	cborTagGeometryMultiPoint = 91
 
-	// A Geometry MultiLine represented by an array with one or more lines (Tag 89).
-	// cborTagGeometryMultiLine = 92.
+	// cborTagGeometryMultiLine represents a Geometry MultiLine as an array with one or more lines (Tag 89).
+	cborTagGeometryMultiLine = 92
 
-	// A Geometry MultiPolygon represented by an array with one or more polygons (Tag 90).
-	// cborTagGeometryMultiPolygon = 93.
+	// cborTagGeometryMultiPolygon represents a Geometry MultiPolygon as an array with one or more polygons (Tag 90).
+	cborTagGeometryMultiPolygon = 93
 
-	// A Geometry Collection represented by an array with one or more geometry values
+	// cborTagGeometryCollection represents a Geometry Collection as an array with one or more geometry values
 	// (Tag 88, Tag 89, Tag 90, Tag 91, Tag 92, Tag 93 or Tag 94).
-	// cborTagGeometryCollection = 94.
+	cborTagGeometryCollection = 94
+)
+
+const (
+	cborMajorTypeShift = 5
+	cborMajorTypeTag   = 6
 )
 
 var encodedNull = []byte{0xf6}
 
+// splitTag returns the tag number and the content of the given data item.
+// If the data item is not tagged, ok is false and the data is returned as is.
+func splitTag(data []byte) (uint64, []byte, bool, error) {
+	if len(data) < 1 || data[0]>>cborMajorTypeShift != cborMajorTypeTag {
+		return 0, data, false, nil
+	}
+
+	var tag cbor.RawTag
+
+	if err := cbor.Unmarshal(data, &tag); err != nil {
+		return 0, nil, false, fmt.Errorf("failed to unmarshal tag: %w", err)
+	}
+
+	return tag.Number, tag.Content, true, nil
+}
+
+// marshalTagged encodes the given content wrapped in a tag with the given number.
+func marshalTagged(number uint64, content any) ([]byte, error) {
+	raw, err := cbor.Marshal(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal tag content: %w", err)
+	}
+
+	data, err := cbor.Marshal(cbor.RawTag{
+		Number:  number,
+		Content: raw,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal raw tag: %w", err)
+	}
+
+	return data, nil
+}
+
+// unmarshalTagged decodes the content of the given data item into val.
+// The data item must either be untagged or carry the expected tag number.
+func unmarshalTagged(data []byte, number uint64, val any) error {
+	tagNumber, content, tagged, err := splitTag(data)
+	if err != nil {
+		return err
+	}
+
+	if tagged && tagNumber != number {
+		return fmt.Errorf("%w: expected tag %d, got %d", ErrDataInvalid, number, tagNumber)
+	}
+
+	if err := cbor.Unmarshal(content, val); err != nil {
+		return fmt.Errorf("failed to unmarshal tag content: %w", err)
+	}
+
+	return nil
+}
+
+//
+// -- DECODING
+//
+
+// decodeTag describes the type a tagged value is
+// decoded into when the target is an interface (any).
+type decodeTag struct {
+	number uint64
+	typ    reflect.Type
+	custom bool
+}
+
+// decodeTags contains all tags of SurrealDB that are registered
+// in the decoding mode of the client. Tags 0 and 1 are not part of
+// this list, because they are handled by the cbor library itself.
+var decodeTags = []decodeTag{
+	{number: CBORTagNone, typ: reflect.TypeFor[None]()},
+	{number: cborTagTable, typ: reflect.TypeFor[Table]()},
+	{number: cborTagRecordID, typ: reflect.TypeFor[ID]()},
+	{number: cborTagUUIDString, typ: reflect.TypeFor[uuidString]()},
+	{number: cborTagDecimal, typ: reflect.TypeFor[Decimal]()},
+	{number: cborTagDatetime, typ: reflect.TypeFor[DateTime]()},
+	{number: cborTagDurationString, typ: reflect.TypeFor[durationString]()},
+	{number: cborTagDuration, typ: reflect.TypeFor[Duration]()},
+	{number: cborTagFuture, typ: reflect.TypeFor[Future]()},
+	{number: CBORTagUUID, typ: reflect.TypeFor[UUID]()},
+	{number: cborTagRange, typ: reflect.TypeFor[Range]()},
+	{number: cborTagFile, typ: reflect.TypeFor[File]()},
+	{number: cborTagGeometryPoint, typ: reflect.TypeFor[GeometryPoint]()},
+	{number: cborTagGeometryLine, typ: reflect.TypeFor[GeometryLine]()},
+	{number: cborTagGeometryPolygon, typ: reflect.TypeFor[GeometryPolygon]()},
+	{number: cborTagGeometryMultiPoint, typ: reflect.TypeFor[GeometryMultiPoint]()},
+	{number: cborTagGeometryMultiLine, typ: reflect.TypeFor[GeometryMultiLine]()},
+	{number: cborTagGeometryMultiPolygon, typ: reflect.TypeFor[GeometryMultiPolygon]()},
+	{number: cborTagGeometryCollection, typ: reflect.TypeFor[GeometryCollection]()},
+}
+
+// newTagSets creates the tag sets for encoding and decoding.
+// Custom tags take precedence over the tags of this package.
+// In contrast to the types of this package, custom types are
+// not expected to write their own tag, so they are registered
+// for encoding as well.
+func newTagSets(custom []decodeTag) (cbor.TagSet, cbor.TagSet, error) {
+	encTags := cbor.NewTagSet()
+	decTags := cbor.NewTagSet()
+
+	registered := make(map[uint64]bool, len(custom)+len(decodeTags))
+
+	for _, tag := range slices.Concat(custom, decodeTags) {
+		if registered[tag.number] {
+			continue
+		}
+
+		registered[tag.number] = true
+
+		opts := cbor.TagOptions{DecTag: cbor.DecTagRequired, EncTag: cbor.EncTagNone}
+
+		if tag.custom {
+			opts.EncTag = cbor.EncTagRequired
+
+			if err := encTags.Add(opts, tag.typ, tag.number); err != nil {
+				return nil, nil, fmt.Errorf("failed to register tag %d: %w", tag.number, err)
+			}
+		}
+
+		if err := decTags.Add(opts, tag.typ, tag.number); err != nil {
+			return nil, nil, fmt.Errorf("failed to register tag %d: %w", tag.number, err)
+		}
+	}
+
+	return encTags, decTags, nil
+}
+
+// valueUnmarshal decodes values nested in types of this package (like the
+// identifier of a record ID) the same way the client decodes untyped results.
+// Custom tags registered with WithTag are not known here, because the
+// UnmarshalCBOR methods have no access to the decode mode of the client.
+var valueUnmarshal = func() Unmarshal {
+	_, decTags, err := newTagSets(nil)
+	if err != nil {
+		panic(err) // the tags of this package are always valid
+	}
+
+	dec, err := cbor.DecOptions{
+		DefaultMapType: reflect.TypeFor[map[string]any](),
+	}.DecModeWithTags(decTags)
+	if err != nil {
+		panic(err) // the options are always valid
+	}
+
+	return newUnmarshal(dec)
+}()
+
+// durationString is the type registered for tag 13. It only exists because
+// the same type cannot be registered for multiple tags. Values of this
+// type are replaced with a Duration after decoding (see normalize).
+type durationString Duration
+
+func (d *durationString) UnmarshalCBOR(data []byte) error {
+	return (*Duration)(d).UnmarshalCBOR(data)
+}
+
+// uuidString is the type registered for tag 9. Values of this
+// type are replaced with a UUID after decoding (see normalize).
+type uuidString UUID
+
+func (u *uuidString) UnmarshalCBOR(data []byte) error {
+	return (*UUID)(u).UnmarshalCBOR(data)
+}
+
+func newUnmarshal(dec cbor.DecMode) Unmarshal {
+	return func(buf []byte, val any) error {
+		if err := dec.Unmarshal(buf, val); err != nil {
+			return err //nolint:wrapcheck // keep the original error of the decoder
+		}
+
+		normalize(reflect.ValueOf(val))
+
+		return nil
+	}
+}
+
+// decodeReplacements maps types the decoder produces for interface values (any)
+// to functions returning the value that should be used instead.
+var decodeReplacements = map[reflect.Type]func(val reflect.Value) reflect.Value{
+	reflect.TypeFor[time.Time](): func(val reflect.Value) reflect.Value {
+		return reflect.ValueOf(DateTime{Time: val.Interface().(time.Time)}) //nolint:forcetypeassert // checked by key
+	},
+	reflect.TypeFor[durationString](): func(val reflect.Value) reflect.Value {
+		return reflect.ValueOf(Duration(val.Interface().(durationString))) //nolint:forcetypeassert // checked by key
+	},
+	reflect.TypeFor[uuidString](): func(val reflect.Value) reflect.Value {
+		return reflect.ValueOf(UUID(val.Interface().(uuidString))) //nolint:forcetypeassert // checked by key
+	},
+	reflect.TypeFor[ID](): func(val reflect.Value) reflect.Value {
+		ptr := reflect.New(val.Type())
+		ptr.Elem().Set(val)
+
+		return ptr
+	},
+}
+
+// normalize replaces decoded values within interfaces (any) that
+// cannot be mapped to the types of this package by the decoder itself.
+// This is the case for IANA tags 0 and 1, which are always decoded into
+// a time.Time, as well as the types only registered for technical reasons.
+// Furthermore, record IDs are always represented as *ID.
+func normalize(val reflect.Value) {
+	if !val.IsValid() || !mayHoldInterface(val.Type()) {
+		return
+	}
+
+	switch val.Kind() { //nolint:exhaustive // only container kinds are relevant
+
+	case reflect.Pointer:
+		if !val.IsNil() {
+			normalize(val.Elem())
+		}
+
+	case reflect.Interface:
+		if val.IsNil() {
+			return
+		}
+
+		if replaced, ok := replaceDecoded(val.Elem()); ok {
+			if val.CanSet() {
+				val.Set(replaced)
+			}
+
+			return
+		}
+
+		normalize(val.Elem())
+
+	case reflect.Slice, reflect.Array:
+		for i := range val.Len() {
+			normalize(val.Index(i))
+		}
+
+	case reflect.Map:
+		normalizeMap(val)
+
+	case reflect.Struct:
+		for i := range val.NumField() {
+			if val.Type().Field(i).IsExported() {
+				normalize(val.Field(i))
+			}
+		}
+	}
+}
+
+func normalizeMap(val reflect.Value) {
+	iter := val.MapRange()
+
+	for iter.Next() {
+		elem := iter.Value()
+
+		switch elem.Kind() { //nolint:exhaustive // only container kinds are relevant
+
+		case reflect.Interface:
+			if elem.IsNil() {
+				continue
+			}
+
+			if replaced, ok := replaceDecoded(elem.Elem()); ok {
+				val.SetMapIndex(iter.Key(), replaced)
+
+				continue
+			}
+
+			normalize(elem.Elem())
+
+		case reflect.Pointer, reflect.Slice, reflect.Map:
+			normalize(elem)
+
+		case reflect.Struct, reflect.Array:
+			if !mayHoldInterface(elem.Type()) {
+				continue
+			}
+
+			// Map values are not addressable, so they must be copied.
+			elemCopy := reflect.New(elem.Type()).Elem()
+			elemCopy.Set(elem)
+			normalize(elemCopy)
+			val.SetMapIndex(iter.Key(), elemCopy)
+		}
+	}
+}
+
+func replaceDecoded(val reflect.Value) (reflect.Value, bool) {
+	replace, ok := decodeReplacements[val.Type()]
+	if !ok {
+		return reflect.Value{}, false
+	}
+
+	return replace(val), true
+}
+
+var interfaceTypes sync.Map // map[reflect.Type]bool
+
+// mayHoldInterface reports whether values of the given type
+// can (transitively) contain any exported interface value.
+func mayHoldInterface(typ reflect.Type) bool {
+	if cached, ok := interfaceTypes.Load(typ); ok {
+		return cached.(bool) //nolint:forcetypeassert // only bool values are stored
+	}
+
+	// Assume true while the type is inspected to support recursive types.
+	interfaceTypes.Store(typ, true)
+
+	res := false
+
+	switch typ.Kind() { //nolint:exhaustive // only container kinds are relevant
+
+	case reflect.Interface:
+		res = true
+
+	case reflect.Pointer, reflect.Slice, reflect.Array:
+		res = mayHoldInterface(typ.Elem())
+
+	case reflect.Map:
+		res = mayHoldInterface(typ.Elem())
+
+	case reflect.Struct:
+		for i := range typ.NumField() {
+			if typ.Field(i).IsExported() && mayHoldInterface(typ.Field(i).Type) {
+				res = true
+
+				break
+			}
+		}
+	}
+
+	interfaceTypes.Store(typ, res)
+
+	return res
+}
+
+// ZeroAsNone encodes the zero value of T as NONE.
+// Please note that this conflates the zero value with the absence of a value.
+// Use Optional to distinguish between NONE, null and an actual value.
 type ZeroAsNone[T comparable] struct {
 	Value T
 }
@@ -118,3 +494,153 @@ func (n *ZeroAsNone[T]) UnmarshalCBOR(data []byte) error {
 
 	return nil
 }
+
+//
+// -- OPTIONAL
+//
+
+type optionState uint8
+
+const (
+	optionUnset optionState = iota
+	optionNone
+	optionNull
+	optionSome
+)
+
+var encodedUndefined = []byte{0xf7}
+
+// Optional represents a value that is either NONE, null or some value of T.
+//
+// The zero value is NONE. It is omitted by struct fields tagged with "omitzero",
+// so that e.g. Merge leaves the respective field untouched. To explicitly remove
+// a field with Merge, use Unset, which is NONE as well, but is never omitted.
+//
+// If T is or contains an interface type, its value is decoded with the tags of
+// this package only. Custom tags registered with WithTag are not applied, so
+// such values are decoded as cbor.Tag instead. Use a concrete type for T instead.
+type Optional[T any] struct {
+	value T
+	state optionState
+}
+
+// Some returns an option holding the given value.
+func Some[T any](val T) Optional[T] {
+	return Optional[T]{value: val, state: optionSome}
+}
+
+// Null returns an option holding null.
+func Null[T any]() Optional[T] {
+	return Optional[T]{state: optionNull}
+}
+
+// Unset returns an option that is explicitly NONE.
+// In contrast to the zero value, it is not omitted by "omitzero".
+func Unset[T any]() Optional[T] {
+	return Optional[T]{state: optionNone}
+}
+
+// IsNone reports whether the option is NONE (absent).
+func (o Optional[T]) IsNone() bool {
+	return o.state == optionUnset || o.state == optionNone
+}
+
+// IsNull reports whether the option is null.
+func (o Optional[T]) IsNull() bool {
+	return o.state == optionNull
+}
+
+// IsSome reports whether the option holds a value.
+func (o Optional[T]) IsSome() bool {
+	return o.state == optionSome
+}
+
+// IsZero reports whether the option is the zero value.
+// It is used by the encoder for fields tagged with "omitzero".
+func (o Optional[T]) IsZero() bool {
+	return o.state == optionUnset
+}
+
+// Get returns the value and whether the option holds one.
+func (o Optional[T]) Get() (T, bool) {
+	return o.value, o.state == optionSome
+}
+
+// OrElse returns the value of the option or the given fallback.
+func (o Optional[T]) OrElse(fallback T) T {
+	if o.state == optionSome {
+		return o.value
+	}
+
+	return fallback
+}
+
+func (o *Optional[T]) MarshalCBOR() ([]byte, error) {
+	switch o.state {
+
+	case optionUnset, optionNone:
+		return marshalNone()
+
+	case optionNull:
+		return encodedNull, nil
+
+	default:
+		data, err := cbor.Marshal(o.value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal value: %w", err)
+		}
+
+		return data, nil
+	}
+}
+
+func (o *Optional[T]) UnmarshalCBOR(data []byte) error {
+	var zero T
+
+	switch {
+
+	case bytes.Equal(data, encodedNull):
+		*o = Optional[T]{value: zero, state: optionNull}
+
+		return nil
+
+	case bytes.Equal(data, encodedUndefined):
+		*o = Optional[T]{value: zero, state: optionNone}
+
+		return nil
+	}
+
+	tagNumber, _, tagged, err := splitTag(data)
+	if err != nil {
+		return err
+	}
+
+	if tagged && tagNumber == CBORTagNone {
+		*o = Optional[T]{value: zero, state: optionNone}
+
+		return nil
+	}
+
+	var val T
+
+	if err := valueUnmarshal(data, &val); err != nil {
+		return fmt.Errorf("failed to unmarshal value: %w", err)
+	}
+
+	*o = Optional[T]{value: val, state: optionSome}
+
+	return nil
+}
+
+// marshalNone returns the encoded NONE value.
+func marshalNone() ([]byte, error) {
+	data, err := cbor.Marshal(cbor.RawTag{
+		Number:  CBORTagNone,
+		Content: encodedNull,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal none: %w", err)
+	}
+
+	return data, nil
+}
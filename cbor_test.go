@@ -0,0 +1,102 @@
+package sdbc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+	"gotest.tools/v3/assert"
+)
+
+func TestOptionalMarshal(t *testing.T) {
+	t.Parallel()
+
+	type model struct {
+		Name     Optional[string] `cbor:"name,omitzero"`
+		Nick     Optional[string] `cbor:"nick,omitzero"`
+		Age      Optional[int]    `cbor:"age,omitzero"`
+		Email    Optional[string] `cbor:"email,omitzero"`
+		Required Optional[int]    `cbor:"required"`
+	}
+
+	data, err := cbor.Marshal(model{
+		Name:  Some("some_name"),
+		Nick:  Unset[string](),
+		Email: Null[string](),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out map[string]any
+
+	if err := cbor.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.DeepEqual(t, map[string]any{
+		"name":     "some_name",
+		"nick":     cbor.Tag{Number: CBORTagNone, Content: nil},
+		"email":    nil,
+		"required": cbor.Tag{Number: CBORTagNone, Content: nil},
+	}, out)
+}
+
+func TestOptionalUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	type model struct {
+		Null    Optional[string]   `cbor:"null"`
+		None    Optional[string]   `cbor:"none"`
+		Some    Optional[int]      `cbor:"some"`
+		Date    Optional[DateTime] `cbor:"date"`
+		Missing Optional[string]   `cbor:"missing"`
+	}
+
+	now := time.Unix(time.Now().Unix(), 0)
+
+	data, err := cbor.Marshal(map[string]any{
+		"null": nil,
+		"none": &None{},
+		"some": 42,
+		"date": &DateTime{now},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out model
+
+	if err := cbor.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Check(t, out.Null.IsNull())
+	assert.Check(t, !out.Null.IsNone())
+	assert.Check(t, out.None.IsNone())
+	assert.Check(t, !out.None.IsNull())
+	assert.Check(t, out.Missing.IsNone())
+	assert.Check(t, out.Missing.IsZero())
+
+	val, ok := out.Some.Get()
+	assert.Check(t, ok)
+	assert.Equal(t, 42, val)
+	assert.Equal(t, "fallback", out.None.OrElse("fallback"))
+
+	date, ok := out.Date.Get()
+	assert.Check(t, ok)
+	assert.Check(t, now.Equal(date.Time))
+}
+
+func TestNilPointerAsNull(t *testing.T) {
+	t.Parallel()
+
+	for _, val := range []cbor.Marshaler{(*DateTime)(nil), (*Duration)(nil), (*Decimal)(nil)} {
+		data, err := val.MarshalCBOR()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.DeepEqual(t, encodedNull, data)
+	}
+}
@@ -0,0 +1,368 @@
+package sdbc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+const (
+	defaultChangefeedInterval = 1 * time.Second
+	defaultChangefeedLimit    = 100
+)
+
+var ErrConflictingChangefeedStart = errors.New("changefeed options since and since time are mutually exclusive")
+
+// ChangeKind defines the kind of change of a ChangeEvent.
+type ChangeKind string
+
+const (
+	ChangeCreate ChangeKind = "create"
+	ChangeUpdate ChangeKind = "update"
+	ChangeDelete ChangeKind = "delete"
+)
+
+// ChangeEvent is a single change of a record read from a changefeed.
+type ChangeEvent struct {
+	// Table is the table the change belongs to.
+	Table Table
+
+	// Versionstamp identifies the change set the change is part of.
+	Versionstamp uint64
+
+	// Kind is the kind of change. Note that SurrealDB currently reports
+	// created records as updates, so ChangeCreate is only used if the
+	// server reports creations separately.
+	Kind ChangeKind
+
+	// ID is the ID of the changed record.
+	ID *ID
+
+	// Record is the CBOR encoded record after the change. For deletions,
+	// it contains what the server reports (usually only the ID).
+	// Use Client.Unmarshal to decode it.
+	Record []byte
+}
+
+// CheckpointStore persists the position of changefeed consumers,
+// so that they can resume where they left off after a restart.
+type CheckpointStore interface {
+	// Load returns the versionstamp of the last processed change set
+	// of the given consumer. It reports false if there is none.
+	Load(ctx context.Context, name string) (uint64, bool, error)
+
+	// Save stores the versionstamp of the last processed
+	// change set of the given consumer.
+	Save(ctx context.Context, name string, versionstamp uint64) error
+}
+
+// NewMemoryCheckpointStore creates a CheckpointStore that keeps the
+// checkpoints in memory. They are lost when the process exits.
+func NewMemoryCheckpointStore() *MemoryCheckpointStore {
+	return &MemoryCheckpointStore{
+		store: map[string]uint64{},
+	}
+}
+
+// MemoryCheckpointStore is a CheckpointStore that keeps the checkpoints in memory.
+type MemoryCheckpointStore struct {
+	mut   sync.RWMutex
+	store map[string]uint64
+}
+
+func (s *MemoryCheckpointStore) Load(_ context.Context, name string) (uint64, bool, error) {
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+
+	versionstamp, ok := s.store[name]
+
+	return versionstamp, ok, nil
+}
+
+func (s *MemoryCheckpointStore) Save(_ context.Context, name string, versionstamp uint64) error {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	s.store[name] = versionstamp
+
+	return nil
+}
+
+// ChangefeedOptions configures a changefeed consumer.
+type ChangefeedOptions struct {
+	// Name identifies the consumer within the checkpoint store.
+	// If not set, the name of the table is used.
+	Name string
+
+	// Store persists the checkpoints of the consumer.
+	// If not set, the checkpoints are kept in memory.
+	Store CheckpointStore
+
+	// Since is the versionstamp to start reading from, if there is no checkpoint.
+	Since uint64
+
+	// SinceTime is the time to start reading from, if there is no checkpoint.
+	// It must not be set together with Since.
+	SinceTime time.Time
+
+	// Interval is the time to wait before polling again, after all changes
+	// have been read. If not set, the default interval is 1 second.
+	Interval time.Duration
+
+	// Limit is the maximum number of change sets read at once.
+	// If not set, the default limit is 100.
+	Limit int
+}
+
+// Changefeed reads the changes of the given table, which must be defined with
+// a CHANGEFEED clause, and delivers them on the returned channel. The channel
+// is closed when the context is done or the client is closed.
+//
+// The consumer starts at the last checkpoint of the store. If there is none,
+// it starts at ChangefeedOptions.Since or ChangefeedOptions.SinceTime (or at
+// the oldest retained change). The checkpoint of a change set is saved once
+// the first event of a later change set is received from the channel, because
+// this means that all events before have been processed. Thus, events are
+// delivered at least once: After a restart, the events of the last change sets
+// may be delivered again, so the processing should be idempotent.
+//
+// Errors while reading changes or saving checkpoints are logged
+// and the operation is retried after the interval.
+func (c *Client) Changefeed(ctx context.Context, table Table, opts ChangefeedOptions) (<-chan ChangeEvent, error) {
+	if err := table.Validate(); err != nil {
+		return nil, err
+	}
+
+	if opts.Since > 0 && !opts.SinceTime.IsZero() {
+		return nil, ErrConflictingChangefeedStart
+	}
+
+	feed := &changefeed{
+		client: c,
+		table:  table,
+		opts:   opts,
+		events: make(chan ChangeEvent),
+	}
+
+	if feed.opts.Name == "" {
+		feed.opts.Name = string(table)
+	}
+
+	if feed.opts.Store == nil {
+		feed.opts.Store = NewMemoryCheckpointStore()
+	}
+
+	if feed.opts.Interval <= 0 {
+		feed.opts.Interval = defaultChangefeedInterval
+	}
+
+	if feed.opts.Limit <= 0 {
+		feed.opts.Limit = defaultChangefeedLimit
+	}
+
+	checkpoint, ok, err := feed.opts.Store.Load(ctx, feed.opts.Name)
+	if err != nil {
+		return nil, fmt.Errorf("could not load checkpoint: %w", err)
+	}
+
+	if ok {
+		feed.next = checkpoint + 1
+		feed.resumed = true
+	}
+
+	c.waitGroup.Add(1)
+	go func() {
+		defer c.waitGroup.Done()
+		defer close(feed.events)
+
+		feed.run(ctx)
+	}()
+
+	return feed.events, nil
+}
+
+// changefeed holds the state of a single changefeed consumer.
+type changefeed struct {
+	client *Client
+	table  Table
+	opts   ChangefeedOptions
+	events chan ChangeEvent
+
+	next    uint64 // versionstamp to read from
+	resumed bool   // whether next is set (from a checkpoint or a change set)
+	pending uint64 // versionstamp of the last delivered change set
+	unsaved bool   // whether pending has not been saved yet
+}
+
+func (f *changefeed) run(ctx context.Context) {
+	for {
+		count, err := f.poll(ctx)
+		if err != nil {
+			f.client.logger.ErrorContext(ctx, "Could not read changefeed.", "table", f.table, "error", err)
+		}
+
+		if ctx.Err() != nil || f.client.connCtx.Err() != nil {
+			return
+		}
+
+		// Read the next change sets right away, if the limit was reached.
+		if err == nil && count >= f.opts.Limit {
+			continue
+		}
+
+		select {
+
+		case <-ctx.Done():
+			return
+
+		case <-f.client.connCtx.Done():
+			return
+
+		case <-time.After(f.opts.Interval):
+		}
+	}
+}
+
+// poll reads the next change sets and delivers their events.
+// It returns the number of change sets read.
+func (f *changefeed) poll(ctx context.Context) (int, error) {
+	results, err := f.client.queryResults(ctx, f.query(), nil)
+	if err != nil {
+		return 0, err
+	}
+
+	var sets []changeSet
+
+	if err := f.client.unmarshal(results[0], &sets); err != nil {
+		return 0, fmt.Errorf("could not unmarshal change sets: %w", err)
+	}
+
+	for _, set := range sets {
+		events := f.decode(ctx, set)
+
+		for index, event := range events {
+			if !f.deliver(ctx, event) {
+				return len(sets), nil
+			}
+
+			// The first event of this change set has been received, so all
+			// events of the previous change sets have been processed.
+			if index == 0 {
+				f.save(ctx)
+			}
+		}
+
+		f.next = set.Versionstamp + 1
+		f.resumed = true
+
+		saved := !f.unsaved
+		f.pending, f.unsaved = set.Versionstamp, true
+
+		// A change set without events does not need to be processed, so it
+		// can be saved right away, unless previous events are still pending.
+		if len(events) == 0 && saved {
+			f.save(ctx)
+		}
+	}
+
+	return len(sets), nil
+}
+
+func (f *changefeed) query() string {
+	var builder strings.Builder
+
+	builder.WriteString("SHOW CHANGES FOR TABLE " + f.table.String() + " SINCE ")
+
+	switch {
+
+	case f.resumed:
+		builder.WriteString(strconv.FormatUint(f.next, 10))
+
+	case !f.opts.SinceTime.IsZero():
+		formatValue(&builder, DateTime{Time: f.opts.SinceTime})
+
+	default:
+		builder.WriteString(strconv.FormatUint(f.opts.Since, 10))
+	}
+
+	builder.WriteString(" LIMIT " + strconv.Itoa(f.opts.Limit) + ";")
+
+	return builder.String()
+}
+
+// deliver sends the event to the channel.
+// It reports false if the consumer has been stopped.
+func (f *changefeed) deliver(ctx context.Context, event ChangeEvent) bool {
+	select {
+
+	case <-ctx.Done():
+		return false
+
+	case <-f.client.connCtx.Done():
+		return false
+
+	case f.events <- event:
+		return true
+	}
+}
+
+// save stores the checkpoint of the last delivered change set, if necessary.
+func (f *changefeed) save(ctx context.Context) {
+	if !f.unsaved {
+		return
+	}
+
+	if err := f.opts.Store.Save(ctx, f.opts.Name, f.pending); err != nil {
+		f.client.logger.ErrorContext(ctx, "Could not save changefeed checkpoint.",
+			"name", f.opts.Name, "versionstamp", f.pending, "error", err)
+
+		return
+	}
+
+	f.unsaved = false
+}
+
+// changeSet is a single entry of the result of SHOW CHANGES.
+type changeSet struct {
+	Versionstamp uint64                       `cbor:"versionstamp"`
+	Changes      []map[string]cbor.RawMessage `cbor:"changes"`
+}
+
+// decode converts the changes of the set into events.
+// Changes other than record changes (e.g. table definitions) are skipped.
+func (f *changefeed) decode(ctx context.Context, set changeSet) []ChangeEvent {
+	events := make([]ChangeEvent, 0, len(set.Changes))
+
+	for _, change := range set.Changes {
+		for _, kind := range []ChangeKind{ChangeCreate, ChangeUpdate, ChangeDelete} {
+			record, ok := change[string(kind)]
+			if !ok {
+				continue
+			}
+
+			var ident struct {
+				ID *ID `cbor:"id"`
+			}
+
+			if err := f.client.unmarshal(record, &ident); err != nil {
+				f.client.logger.WarnContext(ctx, "Could not decode ID of changed record.", "error", err)
+			}
+
+			events = append(events, ChangeEvent{
+				Table:        f.table,
+				Versionstamp: set.Versionstamp,
+				Kind:         kind,
+				ID:           ident.ID,
+				Record:       record,
+			})
+		}
+	}
+
+	return events
+}
@@ -0,0 +1,215 @@
+package sdbc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/poll"
+)
+
+func TestChangefeed(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	client, cleanup := prepareSurreal(ctx, t)
+	defer cleanup()
+
+	_, err := client.Query(ctx, "DEFINE TABLE some SCHEMALESS CHANGEFEED 1h;", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.Query(ctx, `
+		CREATE some:one SET name = 'one';
+		UPDATE some:one SET name = 'uno';
+		DELETE some:one;
+	`, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store := NewMemoryCheckpointStore()
+
+	feedCtx, cancel := context.WithCancel(ctx)
+
+	events, err := client.Changefeed(feedCtx, "some", ChangefeedOptions{
+		Name:     "consumer",
+		Store:    store,
+		Interval: 100 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var received []ChangeEvent
+
+	for len(received) < 3 {
+		select {
+		case event := <-events:
+			received = append(received, event)
+		case <-time.After(5 * time.Second):
+			t.Fatal("timeout")
+		}
+	}
+
+	cancel()
+
+	assert.Equal(t, ChangeUpdate, received[0].Kind)
+	assert.Equal(t, ChangeUpdate, received[1].Kind)
+	assert.Equal(t, ChangeDelete, received[2].Kind)
+
+	for _, event := range received {
+		assert.Equal(t, Table("some"), event.Table)
+		assert.Check(t, event.ID.Equal(StringID("some", "one")))
+	}
+
+	var record struct {
+		Name string `cbor:"name"`
+	}
+
+	if err := client.Unmarshal(received[1].Record, &record); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "uno", record.Name)
+
+	// All change sets but the last one are processed for sure.
+
+	poll.WaitOn(t, func(poll.LogT) poll.Result {
+		checkpoint, _, err := store.Load(ctx, "consumer")
+		if err != nil {
+			return poll.Error(err)
+		}
+
+		if checkpoint != received[1].Versionstamp {
+			return poll.Continue("checkpoint is %d", checkpoint)
+		}
+
+		return poll.Success()
+	})
+
+	// A new consumer resumes at the checkpoint, so the last event is delivered again.
+
+	events, err = client.Changefeed(ctx, "some", ChangefeedOptions{
+		Name:     "consumer",
+		Store:    store,
+		Interval: 100 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case event := <-events:
+		assert.Equal(t, ChangeDelete, event.Kind)
+		assert.Equal(t, received[2].Versionstamp, event.Versionstamp)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout")
+	}
+}
+
+func TestChangefeedOptions(t *testing.T) {
+	t.Parallel()
+
+	client := &Client{}
+
+	_, err := client.Changefeed(context.Background(), "", ChangefeedOptions{})
+	assert.Check(t, errors.Is(err, ErrTableNameRequired))
+
+	_, err = client.Changefeed(context.Background(), "some", ChangefeedOptions{
+		Since:     1,
+		SinceTime: time.Now(),
+	})
+	assert.Check(t, errors.Is(err, ErrConflictingChangefeedStart))
+}
+
+func TestChangefeedQuery(t *testing.T) {
+	t.Parallel()
+
+	feed := &changefeed{
+		table: "some table",
+		opts:  ChangefeedOptions{Since: 42, Limit: 10},
+	}
+
+	assert.Equal(t, "SHOW CHANGES FOR TABLE `some table` SINCE 42 LIMIT 10;", feed.query())
+
+	feed.opts = ChangefeedOptions{
+		SinceTime: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Limit:     10,
+	}
+
+	assert.Equal(t, "SHOW CHANGES FOR TABLE `some table` SINCE d'2024-01-02T03:04:05Z' LIMIT 10;", feed.query())
+
+	feed.next, feed.resumed = 7, true
+
+	assert.Equal(t, "SHOW CHANGES FOR TABLE `some table` SINCE 7 LIMIT 10;", feed.query())
+}
+
+func TestChangefeedDecode(t *testing.T) {
+	t.Parallel()
+
+	marshal, unmarshal := newTestCodec(t, nil)
+
+	feed := &changefeed{
+		client: &Client{options: applyOptions(nil), unmarshal: unmarshal},
+		table:  "some",
+	}
+
+	encode := func(val any) cbor.RawMessage {
+		t.Helper()
+
+		data, err := marshal(val)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return data
+	}
+
+	set := changeSet{
+		Versionstamp: 65536,
+		Changes: []map[string]cbor.RawMessage{
+			{"define_table": encode(map[string]any{"name": "some"})},
+			{"update": encode(map[string]any{"id": StringID("some", "a"), "name": "a"})},
+			{"create": encode(map[string]any{"id": StringID("some", "b")})},
+			{"delete": encode(map[string]any{"id": StringID("some", "c")})},
+		},
+	}
+
+	events := feed.decode(context.Background(), set)
+
+	assert.Equal(t, 3, len(events))
+
+	assert.Equal(t, ChangeUpdate, events[0].Kind)
+	assert.Equal(t, ChangeCreate, events[1].Kind)
+	assert.Equal(t, ChangeDelete, events[2].Kind)
+
+	for index, id := range []string{"a", "b", "c"} {
+		assert.Equal(t, uint64(65536), events[index].Versionstamp)
+		assert.Equal(t, Table("some"), events[index].Table)
+		assert.Check(t, events[index].ID.Equal(StringID("some", id)))
+	}
+}
+
+func TestMemoryCheckpointStore(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store := NewMemoryCheckpointStore()
+
+	_, ok, err := store.Load(ctx, "some")
+	assert.NilError(t, err)
+	assert.Check(t, !ok)
+
+	assert.NilError(t, store.Save(ctx, "some", 42))
+
+	versionstamp, ok, err := store.Load(ctx, "some")
+	assert.NilError(t, err)
+	assert.Check(t, ok)
+	assert.Equal(t, uint64(42), versionstamp)
+}
@@ -34,10 +34,12 @@ const (
 )
 
 var (
-	regexName = regexp.MustCompile("^[A-Za-z0-9_]+$")
+	regexName      = regexp.MustCompile("^[A-Za-z0-9_]+$")
+	regexLiveOwner = regexp.MustCompile("^[A-Za-z0-9]+$")
 
 	ErrInvalidNamespaceName = errors.New("invalid namespace name")
 	ErrInvalidDatabaseName  = errors.New("invalid database name")
+	ErrInvalidLiveOwner     = errors.New("invalid live param owner")
 
 	ErrContextNil = errors.New("context is nil")
 )
@@ -48,8 +50,9 @@ type Client struct {
 	marshal   Marshal
 	unmarshal Unmarshal
 
-	conf  Config
-	token string
+	conf    Config
+	token   string
+	version SemVer
 
 	conn       *websocket.Conn
 	connCtx    context.Context //nolint:containedctx // runtime context is used for websocket connection
@@ -62,6 +65,7 @@ type Client struct {
 	buffers     bufPool
 	requests    *requests
 	liveQueries *liveQueries
+	liveParams  *liveParams
 }
 
 // Config is the configuration for the client.
@@ -120,8 +124,10 @@ func NewClient(ctx context.Context, conf Config, opts ...Option) (*Client, error
 		UTF8:              cbor.UTF8RejectInvalid, // reject invalid UTF-8
 	}
 
-	encTags := cbor.NewTagSet()
-	decTags := cbor.NewTagSet()
+	encTags, decTags, err := newTagSets(client.tags)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cbor tags: %w", err)
+	}
 
 	enc, err := encOpts.EncModeWithTags(encTags)
 	if err != nil {
@@ -134,10 +140,11 @@ func NewClient(ctx context.Context, conf Config, opts ...Option) (*Client, error
 	}
 
 	client.marshal = enc.Marshal
-	client.unmarshal = dec.Unmarshal
+	client.unmarshal = newUnmarshal(dec)
 
 	client.requests = newRequests()
 	client.liveQueries = newLiveQueries()
+	client.liveParams = newLiveParams()
 
 	client.connCtx, client.connCancel = context.WithCancel(ctx)
 
@@ -156,9 +163,9 @@ func (c *Client) openWebsocket() error {
 	c.connMutex.Lock()
 	defer c.connMutex.Unlock()
 
-	// make sure the previous connection is closed
+	// make sure the previous connection is closed (it is already when reconnecting after a failure)
 	if c.conn != nil {
-		if err := c.conn.Close(websocket.StatusServiceRestart, "reconnect"); err != nil {
+		if err := c.conn.Close(websocket.StatusServiceRestart, "reconnect"); err != nil && !errors.Is(err, net.ErrClosed) {
 			return fmt.Errorf("failed to close websocket connection: %w", err)
 		}
 	}
@@ -215,9 +222,12 @@ func (c *Client) checkWebsocketConn(err error) {
 		{
 			c.logger.Error("Websocket connection closed unexpectedly. Trying to reconnect.", "error", err)
 
-			if err := c.openWebsocket(); err != nil {
+			err := c.openWebsocket()
+			if err != nil {
 				c.logger.Error("Could not reconnect to websocket.", "error", err)
 			}
+
+			c.metrics.IncReconnects(err)
 		}
 	}
 }
@@ -231,6 +241,14 @@ func (c *Client) init(ctx context.Context, conf Config) error {
 		return ErrInvalidDatabaseName
 	}
 
+	if c.liveOwner != "" && !regexLiveOwner.MatchString(c.liveOwner) {
+		return ErrInvalidLiveOwner
+	}
+
+	if err := c.initVersion(ctx); err != nil {
+		return err
+	}
+
 	if err := c.signIn(ctx, conf.Username, conf.Password); err != nil {
 		return fmt.Errorf("failed to sign in: %w", err)
 	}
@@ -257,6 +275,67 @@ func (c *Client) init(ctx context.Context, conf Config) error {
 		return fmt.Errorf("could not define database: %w", err)
 	}
 
+	if c.liveOwner != "" {
+		if err := c.sweepLiveParams(ctx); err != nil {
+			return fmt.Errorf("could not remove leftover live params: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// initVersion fetches the version of the server and
+// checks it against the minimum version (if configured).
+// If the version cannot be parsed and no minimum version is configured,
+// a warning is logged and the version stays unknown.
+func (c *Client) initVersion(ctx context.Context) error {
+	raw, err := c.Version(ctx)
+	if err != nil {
+		return err
+	}
+
+	version, err := ParseSemVer(raw)
+	if err != nil {
+		if !c.minVersion.IsZero() {
+			return fmt.Errorf("failed to parse server version: %w", err)
+		}
+
+		c.logger.WarnContext(ctx, "Could not parse server version.", "version", raw, "error", err)
+
+		return nil
+	}
+
+	c.version = version
+
+	if !c.minVersion.IsZero() && !version.AtLeast(c.minVersion) {
+		return fmt.Errorf("%w: server version %s is lower than the required version %s",
+			ErrUnsupportedByServer, version, c.minVersion)
+	}
+
+	return nil
+}
+
+// ServerVersion returns the version of the connected server,
+// as determined when the client was created.
+func (c *Client) ServerVersion() SemVer {
+	return c.version
+}
+
+// requireVersion returns ErrUnsupportedByServer if the server version is lower
+// than the given version. Pre-releases are treated like the final release,
+// so that e.g. 2.0.0-beta.1 supports all features introduced with 2.0.0.
+// If the server version is unknown, no check is performed.
+func (c *Client) requireVersion(feature string, required SemVer) error {
+	if c.version.IsZero() {
+		return nil
+	}
+
+	release := SemVer{Major: c.version.Major, Minor: c.version.Minor, Patch: c.version.Patch}
+
+	if !release.AtLeast(required) {
+		return fmt.Errorf("%w: %s requires version %s, got %s", ErrUnsupportedByServer, feature, required, c.version)
+	}
+
 	return nil
 }
 
@@ -299,6 +378,14 @@ func (c *Client) Close() error {
 
 	c.logger.Info("Closing client.")
 
+	// Live queries are not killed regularly when the client is closed,
+	// so the params defined for them need to be removed explicitly.
+	if c.liveParams.len() > 0 {
+		ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+		c.removeLiveParams(ctx, c.liveParams.takeAll())
+		cancel()
+	}
+
 	err := c.conn.Close(websocket.StatusNormalClosure, "closing client")
 	if err != nil && !errors.Is(err, net.ErrClosed) && !errors.Is(err, io.EOF) {
 		// TODO: is it really properly closed despite the io.EOF error?
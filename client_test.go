@@ -3,6 +3,7 @@ package sdbc
 import (
 	"context"
 	"errors"
+	"log/slog"
 	"testing"
 
 	"github.com/brianvoe/gofakeit/v7"
@@ -84,3 +85,82 @@ func TestInvalidNamespaceAndDatabaseNames(t *testing.T) {
 
 	assert.Check(t, errors.Is(err, ErrInvalidDatabaseName))
 }
+
+func TestMinServerVersion(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	username := gofakeit.Username()
+	password := gofakeit.Password(true, true, true, true, true, 32)
+
+	dbHost, dbCleanup := prepareDatabase(ctx, t, username, password)
+	defer dbCleanup()
+
+	conf := Config{
+		Host:      dbHost,
+		Username:  username,
+		Password:  password,
+		Namespace: gofakeit.FirstName(),
+		Database:  gofakeit.LastName(),
+	}
+
+	client, err := NewClient(ctx, conf, WithMinServerVersion(SemVer{Major: 2}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Check(t, client.ServerVersion().AtLeast(SemVer{Major: 2}))
+	assert.NilError(t, client.Close())
+
+	_, err = NewClient(ctx, conf, WithMinServerVersion(SemVer{Major: 99}))
+	assert.Check(t, errors.Is(err, ErrUnsupportedByServer))
+}
+
+func TestInitVersionUnparsable(t *testing.T) {
+	t.Parallel()
+
+	handler := func(_ request) (any, *responseError) {
+		return "surrealdb-nightly", nil
+	}
+
+	logger := newLogger(t, nil)
+
+	client := newWebsocketTestClient(t, handler, WithLogger(slog.New(logger)))
+
+	// Without a minimum version, the version stays unknown.
+	assert.NilError(t, client.initVersion(context.Background()))
+	assert.Check(t, client.ServerVersion().IsZero())
+	assert.Check(t, logger.hasRecordMsg("Could not parse server version."))
+
+	client = newWebsocketTestClient(t, handler, WithMinServerVersion(SemVer{Major: 2}))
+
+	err := client.initVersion(context.Background())
+	assert.Check(t, errors.Is(err, ErrInvalidVersion))
+}
+
+func TestRequireVersion(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	client := &Client{version: SemVer{Major: 1, Minor: 5, Patch: 4}}
+
+	_, err := client.Upsert(ctx, NewID("some"), nil)
+	assert.Check(t, errors.Is(err, ErrUnsupportedByServer))
+
+	_, err = client.GraphQL(ctx, GraphqlRequest{Query: "{ some { id } }"})
+	assert.Check(t, errors.Is(err, ErrUnsupportedByServer))
+
+	_, err = client.Run(ctx, "time::now", nil, nil)
+	assert.Check(t, errors.Is(err, ErrUnsupportedByServer))
+
+	_, err = client.RunModel(ctx, "price", "1.0.0", []float64{1, 2})
+	assert.Check(t, errors.Is(err, ErrUnsupportedByServer))
+
+	client.version = SemVer{Major: 2, PreRelease: "beta.1"}
+	assert.NilError(t, client.requireVersion("upsert", version2))
+
+	client.version = SemVer{}
+	assert.NilError(t, client.requireVersion("upsert", version2))
+}
@@ -9,11 +9,18 @@ import (
 
 var (
 	ErrChannelClosed               = errors.New("channel closed")
+	ErrConflictingInsertOptions    = errors.New("insert options ignore and on duplicate are mutually exclusive")
 	ErrCouldNotGetLiveQueryChannel = errors.New("could not get live query channel")
 	ErrCouldNotSelectDatabase      = errors.New("could not select database")
 	ErrEmptyResponse               = errors.New("empty response")
 	ErrExpectedTextMessage         = fmt.Errorf("expected message of type text (%d)", websocket.MessageBinary)
+	ErrInvalidQuery                = errors.New("invalid query")
+	ErrLiveNotificationDropped     = errors.New("live notification dropped")
+	ErrMultipleStatements          = errors.New("query must consist of a single statement")
+	ErrOptionNotSupported          = errors.New("option not supported")
 	ErrResponseNotOkay             = errors.New("response status is not OK")
 	ErrResultWithError             = errors.New("result contains error")
 	ErrTimeoutWaitingForGoroutines = errors.New("internal goroutines did not finish in time")
+	ErrUnexpectedStatus            = errors.New("unexpected http status")
+	ErrUnsupportedByServer         = errors.New("unsupported by server")
 )
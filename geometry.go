@@ -0,0 +1,239 @@
+package sdbc
+
+import (
+	"fmt"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+// Geometry is implemented by all geometry types of this package.
+type Geometry interface {
+	geometry()
+}
+
+//
+// -- POINT
+//
+
+// GeometryPoint is a single position in the form of (longitude, latitude).
+type GeometryPoint struct {
+	Longitude float64
+	Latitude  float64
+}
+
+func (p GeometryPoint) geometry() {}
+
+func (p *GeometryPoint) MarshalCBOR() ([]byte, error) {
+	return marshalTagged(cborTagGeometryPoint, []float64{p.Longitude, p.Latitude})
+}
+
+func (p *GeometryPoint) UnmarshalCBOR(data []byte) error {
+	var val []float64
+
+	if err := unmarshalTagged(data, cborTagGeometryPoint, &val); err != nil {
+		return fmt.Errorf("failed to unmarshal geometry point: %w", err)
+	}
+
+	if len(val) != expectedArrayLength {
+		return fmt.Errorf("%w: expected %d coordinates, got %d", ErrDataInvalid, expectedArrayLength, len(val))
+	}
+
+	p.Longitude = val[0]
+	p.Latitude = val[1]
+
+	return nil
+}
+
+//
+// -- LINE
+//
+
+// GeometryLine is a line consisting of two or more points.
+type GeometryLine []GeometryPoint
+
+func (l GeometryLine) geometry() {}
+
+func (l *GeometryLine) MarshalCBOR() ([]byte, error) {
+	return marshalTagged(cborTagGeometryLine, []GeometryPoint(*l))
+}
+
+func (l *GeometryLine) UnmarshalCBOR(data []byte) error {
+	if err := unmarshalTagged(data, cborTagGeometryLine, (*[]GeometryPoint)(l)); err != nil {
+		return fmt.Errorf("failed to unmarshal geometry line: %w", err)
+	}
+
+	return nil
+}
+
+//
+// -- POLYGON
+//
+
+// GeometryPolygon is a polygon consisting of one or more closed lines.
+// The first line is the exterior ring, any further lines are holes.
+type GeometryPolygon []GeometryLine
+
+func (p GeometryPolygon) geometry() {}
+
+func (p *GeometryPolygon) MarshalCBOR() ([]byte, error) {
+	return marshalTagged(cborTagGeometryPolygon, []GeometryLine(*p))
+}
+
+func (p *GeometryPolygon) UnmarshalCBOR(data []byte) error {
+	if err := unmarshalTagged(data, cborTagGeometryPolygon, (*[]GeometryLine)(p)); err != nil {
+		return fmt.Errorf("failed to unmarshal geometry polygon: %w", err)
+	}
+
+	return nil
+}
+
+//
+// -- MULTI POINT
+//
+
+// GeometryMultiPoint is a collection of one or more points.
+type GeometryMultiPoint []GeometryPoint
+
+func (m GeometryMultiPoint) geometry() {}
+
+func (m *GeometryMultiPoint) MarshalCBOR() ([]byte, error) {
+	return marshalTagged(cborTagGeometryMultiPoint, []GeometryPoint(*m))
+}
+
+func (m *GeometryMultiPoint) UnmarshalCBOR(data []byte) error {
+	if err := unmarshalTagged(data, cborTagGeometryMultiPoint, (*[]GeometryPoint)(m)); err != nil {
+		return fmt.Errorf("failed to unmarshal geometry multi point: %w", err)
+	}
+
+	return nil
+}
+
+//
+// -- MULTI LINE
+//
+
+// GeometryMultiLine is a collection of one or more lines.
+type GeometryMultiLine []GeometryLine
+
+func (m GeometryMultiLine) geometry() {}
+
+func (m *GeometryMultiLine) MarshalCBOR() ([]byte, error) {
+	return marshalTagged(cborTagGeometryMultiLine, []GeometryLine(*m))
+}
+
+func (m *GeometryMultiLine) UnmarshalCBOR(data []byte) error {
+	if err := unmarshalTagged(data, cborTagGeometryMultiLine, (*[]GeometryLine)(m)); err != nil {
+		return fmt.Errorf("failed to unmarshal geometry multi line: %w", err)
+	}
+
+	return nil
+}
+
+//
+// -- MULTI POLYGON
+//
+
+// GeometryMultiPolygon is a collection of one or more polygons.
+type GeometryMultiPolygon []GeometryPolygon
+
+func (m GeometryMultiPolygon) geometry() {}
+
+func (m *GeometryMultiPolygon) MarshalCBOR() ([]byte, error) {
+	return marshalTagged(cborTagGeometryMultiPolygon, []GeometryPolygon(*m))
+}
+
+func (m *GeometryMultiPolygon) UnmarshalCBOR(data []byte) error {
+	if err := unmarshalTagged(data, cborTagGeometryMultiPolygon, (*[]GeometryPolygon)(m)); err != nil {
+		return fmt.Errorf("failed to unmarshal geometry multi polygon: %w", err)
+	}
+
+	return nil
+}
+
+//
+// -- COLLECTION
+//
+
+// GeometryCollection is a collection of one or more geometries of any type.
+type GeometryCollection []Geometry
+
+func (c GeometryCollection) geometry() {}
+
+func (c *GeometryCollection) MarshalCBOR() ([]byte, error) {
+	return marshalTagged(cborTagGeometryCollection, []Geometry(*c))
+}
+
+func (c *GeometryCollection) UnmarshalCBOR(data []byte) error {
+	var items []cbor.RawMessage
+
+	if err := unmarshalTagged(data, cborTagGeometryCollection, &items); err != nil {
+		return fmt.Errorf("failed to unmarshal geometry collection: %w", err)
+	}
+
+	geometries := make(GeometryCollection, 0, len(items))
+
+	for _, item := range items {
+		geometry, err := unmarshalGeometry(item)
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal geometry collection: %w", err)
+		}
+
+		geometries = append(geometries, geometry)
+	}
+
+	*c = geometries
+
+	return nil
+}
+
+// unmarshalGeometry decodes any tagged geometry value.
+func unmarshalGeometry(data []byte) (Geometry, error) {
+	tagNumber, _, tagged, err := splitTag(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if !tagged {
+		return nil, fmt.Errorf("%w: expected tagged geometry", ErrDataInvalid)
+	}
+
+	switch tagNumber {
+
+	case cborTagGeometryPoint:
+		return decodeGeometry[GeometryPoint](data)
+
+	case cborTagGeometryLine:
+		return decodeGeometry[GeometryLine](data)
+
+	case cborTagGeometryPolygon:
+		return decodeGeometry[GeometryPolygon](data)
+
+	case cborTagGeometryMultiPoint:
+		return decodeGeometry[GeometryMultiPoint](data)
+
+	case cborTagGeometryMultiLine:
+		return decodeGeometry[GeometryMultiLine](data)
+
+	case cborTagGeometryMultiPolygon:
+		return decodeGeometry[GeometryMultiPolygon](data)
+
+	case cborTagGeometryCollection:
+		return decodeGeometry[GeometryCollection](data)
+
+	default:
+		return nil, fmt.Errorf("%w: unexpected tag %d for geometry", ErrDataInvalid, tagNumber)
+	}
+}
+
+func decodeGeometry[T Geometry, P interface {
+	*T
+	cbor.Unmarshaler
+}](data []byte) (Geometry, error) {
+	var geometry T
+
+	if err := P(&geometry).UnmarshalCBOR(data); err != nil {
+		return nil, err
+	}
+
+	return geometry, nil
+}
@@ -0,0 +1,230 @@
+package sdbc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	schemeHTTP  = "http"
+	schemeHTTPS = "https"
+
+	pathExport = "/export"
+	pathImport = "/import"
+
+	// maxErrorBodySize is the maximum number of bytes
+	// read from the body of a failed HTTP response.
+	maxErrorBodySize = 4 << 10 // 4 KB
+)
+
+// ExportOptions configures what Export includes. The zero value exports
+// the whole database. All other values require SurrealDB v2.1.0 or later.
+type ExportOptions struct {
+	// Tables limits the export to the given tables.
+	// If empty, all tables are exported.
+	Tables []Table
+
+	ExcludeUsers     bool
+	ExcludeAccesses  bool
+	ExcludeParams    bool
+	ExcludeFunctions bool
+	ExcludeAnalyzers bool
+
+	// ExcludeRecords exports the definitions only.
+	ExcludeRecords bool
+
+	// Versions includes all versions of the records.
+	// This is only supported by versioned storage engines (e.g. SurrealKV).
+	Versions bool
+}
+
+func (o ExportOptions) isZero() bool {
+	return len(o.Tables) == 0 && !o.ExcludeUsers && !o.ExcludeAccesses && !o.ExcludeParams &&
+		!o.ExcludeFunctions && !o.ExcludeAnalyzers && !o.ExcludeRecords && !o.Versions
+}
+
+// exportConfig is the request body of a configured export.
+type exportConfig struct {
+	Users     bool `json:"users"`
+	Accesses  bool `json:"accesses"`
+	Params    bool `json:"params"`
+	Functions bool `json:"functions"`
+	Analyzers bool `json:"analyzers"`
+	Versions  bool `json:"versions"`
+	Records   bool `json:"records"`
+
+	// Tables is either true (all tables) or a list of table names.
+	Tables any `json:"tables"`
+}
+
+// Export writes a SurrealQL dump of the selected database to the writer, using the
+// HTTP endpoint of the server. The dump is streamed as it is received, so
+// on error, the writer may contain a partial dump.
+func (c *Client) Export(ctx context.Context, writer io.Writer, opts ExportOptions) error {
+	method, body := http.MethodGet, io.Reader(nil)
+
+	if !opts.isZero() {
+		if err := c.requireVersion("export options", version21); err != nil {
+			return err
+		}
+
+		conf := exportConfig{
+			Users:     !opts.ExcludeUsers,
+			Accesses:  !opts.ExcludeAccesses,
+			Params:    !opts.ExcludeParams,
+			Functions: !opts.ExcludeFunctions,
+			Analyzers: !opts.ExcludeAnalyzers,
+			Versions:  opts.Versions,
+			Records:   !opts.ExcludeRecords,
+			Tables:    true,
+		}
+
+		if len(opts.Tables) > 0 {
+			tables := make([]string, len(opts.Tables))
+			for index, table := range opts.Tables {
+				tables[index] = string(table)
+			}
+
+			conf.Tables = tables
+		}
+
+		data, err := json.Marshal(conf)
+		if err != nil {
+			return fmt.Errorf("could not marshal export options: %w", err)
+		}
+
+		method, body = http.MethodPost, bytes.NewReader(data)
+	}
+
+	req, err := c.newHTTPRequest(ctx, method, pathExport, body)
+	if err != nil {
+		return err
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	res, err := c.doHTTP(req)
+	if err != nil {
+		return fmt.Errorf("could not export: %w", err)
+	}
+
+	defer res.Body.Close()
+
+	if _, err := io.Copy(writer, res.Body); err != nil {
+		return fmt.Errorf("could not read export: %w", err)
+	}
+
+	return nil
+}
+
+// Import executes the SurrealQL dump read from the reader (e.g. created by Export)
+// on the selected database, using the HTTP endpoint of the server.
+func (c *Client) Import(ctx context.Context, reader io.Reader) error {
+	req, err := c.newHTTPRequest(ctx, http.MethodPost, pathImport, reader)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "text/plain")
+	req.Header.Set("Accept", "application/json")
+
+	res, err := c.doHTTP(req)
+	if err != nil {
+		return fmt.Errorf("could not import: %w", err)
+	}
+
+	defer res.Body.Close()
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return fmt.Errorf("could not read import response: %w", err)
+	}
+
+	// Depending on the server version, the response is either
+	// empty or contains the result of each statement.
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil
+	}
+
+	var results []struct {
+		Status string          `json:"status"`
+		Result json.RawMessage `json:"result"`
+	}
+
+	if err := json.Unmarshal(data, &results); err != nil {
+		return fmt.Errorf("could not decode import response: %w", err)
+	}
+
+	for _, result := range results {
+		if result.Status != "OK" {
+			return fmt.Errorf("could not import: %w: %s", ErrResponseNotOkay, string(result.Result))
+		}
+	}
+
+	return nil
+}
+
+// newHTTPRequest creates a request for the given path of the HTTP endpoint,
+// authenticated with the session token and scoped to the selected namespace
+// and database.
+func (c *Client) newHTTPRequest(ctx context.Context, method, path string, body io.Reader) (*http.Request, error) {
+	requestURL := url.URL{
+		Scheme: schemeHTTP,
+		Host:   c.conf.Host,
+		Path:   path,
+	}
+
+	if c.conf.Secure {
+		requestURL.Scheme = schemeHTTPS
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, requestURL.String(), body)
+	if err != nil {
+		return nil, fmt.Errorf("could not create http request: %w", err)
+	}
+
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	// SurrealDB 2.x renamed the headers, but still accepts the old ones.
+	if c.requireVersion("surreal headers", version2) == nil {
+		req.Header.Set("Surreal-NS", c.conf.Namespace)
+		req.Header.Set("Surreal-DB", c.conf.Database)
+	} else {
+		req.Header.Set("NS", c.conf.Namespace)
+		req.Header.Set("DB", c.conf.Database)
+	}
+
+	return req, nil
+}
+
+// doHTTP sends the request with the configured HTTP client. If the
+// response status is not 200 OK, ErrUnexpectedStatus is returned
+// together with the (shortened) response body.
+func (c *Client) doHTTP(req *http.Request) (*http.Response, error) {
+	c.logger.DebugContext(req.Context(), "Sending HTTP request.", "method", req.Method, "path", req.URL.Path)
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("could not send http request: %w", err)
+	}
+
+	if res.StatusCode == http.StatusOK {
+		return res, nil
+	}
+
+	defer res.Body.Close()
+
+	msg, _ := io.ReadAll(io.LimitReader(res.Body, maxErrorBodySize))
+
+	return nil, fmt.Errorf("%w: %s: %s", ErrUnexpectedStatus, res.Status, strings.TrimSpace(string(msg)))
+}
@@ -0,0 +1,205 @@
+package sdbc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestExportImport(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	client, cleanup := prepareSurreal(ctx, t)
+	defer cleanup()
+
+	_, err := client.Query(ctx, `
+		DEFINE TABLE user SCHEMAFULL;
+		DEFINE FIELD name ON user TYPE string;
+		CREATE user:one SET name = 'one';
+	`, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var dump bytes.Buffer
+
+	if err := client.Export(ctx, &dump, ExportOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Check(t, strings.Contains(dump.String(), "DEFINE TABLE user"), dump.String())
+
+	if _, err := client.Query(ctx, "REMOVE TABLE user;", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := client.Import(ctx, &dump); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := client.Query(ctx, "SELECT VALUE name FROM user:one;", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []basicResponse[[]string]
+
+	if err := client.Unmarshal(res, &names); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.DeepEqual(t, []string{"one"}, names[0].Result)
+}
+
+func TestExport(t *testing.T) {
+	t.Parallel()
+
+	var (
+		method, path, token, namespace string
+		body                           []byte
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, path = r.Method, r.URL.Path
+		token, namespace = r.Header.Get("Authorization"), r.Header.Get("Surreal-NS")
+		body, _ = io.ReadAll(r.Body)
+
+		_, _ = w.Write([]byte("DEFINE TABLE user;"))
+	}))
+	defer server.Close()
+
+	client := newHTTPTestClient(server, SemVer{Major: 2, Minor: 1})
+
+	var dump bytes.Buffer
+
+	if err := client.Export(context.Background(), &dump, ExportOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "DEFINE TABLE user;", dump.String())
+	assert.Equal(t, http.MethodGet, method)
+	assert.Equal(t, pathExport, path)
+	assert.Equal(t, "Bearer token", token)
+	assert.Equal(t, "test", namespace)
+	assert.Equal(t, 0, len(body))
+
+	// Configured exports are sent as POST with the options as JSON body.
+
+	err := client.Export(context.Background(), io.Discard, ExportOptions{
+		Tables:         []Table{"user", "post"},
+		ExcludeUsers:   true,
+		ExcludeRecords: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var conf map[string]any
+
+	if err := json.Unmarshal(body, &conf); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, http.MethodPost, method)
+	assert.DeepEqual(t, map[string]any{
+		"users": false, "accesses": true, "params": true, "functions": true, "analyzers": true,
+		"versions": false, "records": false, "tables": []any{"user", "post"},
+	}, conf)
+
+	// Older servers do not support export options.
+
+	client.version = SemVer{Major: 2}
+
+	err = client.Export(context.Background(), io.Discard, ExportOptions{ExcludeUsers: true})
+	assert.Check(t, errors.Is(err, ErrUnsupportedByServer))
+}
+
+func TestImport(t *testing.T) {
+	t.Parallel()
+
+	var (
+		path, namespace, database string
+		body                      []byte
+		response                  = `[{"status":"OK","result":null}]`
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		namespace, database = r.Header.Get("NS"), r.Header.Get("DB")
+		body, _ = io.ReadAll(r.Body)
+
+		_, _ = w.Write([]byte(response))
+	}))
+	defer server.Close()
+
+	client := newHTTPTestClient(server, SemVer{Major: 1, Minor: 5})
+
+	if err := client.Import(context.Background(), strings.NewReader("CREATE user:one;")); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, pathImport, path)
+	assert.Equal(t, "test", namespace)
+	assert.Equal(t, "test", database)
+	assert.Equal(t, "CREATE user:one;", string(body))
+
+	response = `[{"status":"OK","result":null},{"status":"ERR","result":"Parse error"}]`
+
+	err := client.Import(context.Background(), strings.NewReader("CREATE"))
+	assert.Check(t, errors.Is(err, ErrResponseNotOkay))
+	assert.ErrorContains(t, err, "Parse error")
+
+	response = ""
+
+	assert.NilError(t, client.Import(context.Background(), strings.NewReader("CREATE user:two;")))
+
+	response = "<html>proxy error</html>"
+
+	err = client.Import(context.Background(), strings.NewReader("CREATE user:three;"))
+	assert.ErrorContains(t, err, "could not decode import response")
+}
+
+func TestHTTPUnexpectedStatus(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "There was a problem with authentication", http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	client := newHTTPTestClient(server, SemVer{Major: 2})
+
+	err := client.Export(context.Background(), io.Discard, ExportOptions{})
+	assert.Check(t, errors.Is(err, ErrUnexpectedStatus))
+	assert.ErrorContains(t, err, "401 Unauthorized: There was a problem with authentication")
+
+	err = client.Import(context.Background(), strings.NewReader(""))
+	assert.Check(t, errors.Is(err, ErrUnexpectedStatus))
+}
+
+//
+// -- HELPER
+//
+
+func newHTTPTestClient(server *httptest.Server, version SemVer) *Client {
+	return &Client{
+		options: applyOptions([]Option{WithHTTPClient(server.Client())}),
+		conf: Config{
+			Host:      strings.TrimPrefix(server.URL, "http://"),
+			Namespace: "test",
+			Database:  "test",
+		},
+		token:   "token",
+		version: version,
+	}
+}
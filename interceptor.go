@@ -0,0 +1,98 @@
+package sdbc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Request is an RPC request passed to an Interceptor.
+type Request struct {
+	// Method is the RPC method, e.g. query or select.
+	Method string
+
+	// Params are the parameters of the method, e.g. the query and its variables.
+	Params []any
+}
+
+// Response is the response of an RPC request returned by an Invoker.
+type Response struct {
+	// Result is the raw (CBOR encoded) result of the request.
+	Result []byte
+}
+
+// Invoker sends the request, either to the next interceptor or to the server.
+type Invoker func(ctx context.Context, req Request) (Response, error)
+
+// Interceptor wraps RPC requests, e.g. for auditing, query tagging, retries
+// or guards. It may inspect or modify the request and response, and has to
+// call next to continue the chain. It is set with WithInterceptor.
+type Interceptor func(ctx context.Context, req Request, next Invoker) (Response, error)
+
+// Notification is a live query notification passed to a StreamInterceptor.
+type Notification struct {
+	// LiveID is the ID of the live query.
+	LiveID string
+
+	// Result is the raw (CBOR encoded) notification as delivered
+	// to the channel of the live query, including its action.
+	Result []byte
+}
+
+// NotificationHandler delivers the notification, either to
+// the next stream interceptor or to the channel of the live query.
+type NotificationHandler func(ctx context.Context, notification Notification) error
+
+// StreamInterceptor wraps the delivery of live query notifications. The context
+// is the one the live query was started with. It may inspect or modify the
+// notification and has to call next to deliver it. If next is not called, the
+// notification is skipped. If an error is returned, the notification is
+// considered dropped. It is set with WithStreamInterceptor.
+type StreamInterceptor func(ctx context.Context, notification Notification, next NotificationHandler) error
+
+// intercept sends the request through the chain of interceptors.
+// The first interceptor is the outermost one.
+func (c *Client) intercept(ctx context.Context, req request) ([]byte, error) {
+	next := func(ctx context.Context, req Request) (Response, error) {
+		res, err := c.invoke(ctx, request{Method: req.Method, Params: req.Params})
+
+		return Response{Result: res}, err
+	}
+
+	for index := len(c.interceptors) - 1; index >= 0; index-- {
+		interceptor, inner := c.interceptors[index], next
+
+		next = func(ctx context.Context, req Request) (Response, error) {
+			return interceptor(ctx, req, inner)
+		}
+	}
+
+	res, err := next(ctx, Request{Method: req.Method, Params: req.Params})
+
+	return res.Result, err
+}
+
+// interceptNotification delivers the notification through the chain of
+// stream interceptors. Errors always wrap ErrLiveNotificationDropped.
+func (c *Client) interceptNotification(
+	ctx context.Context,
+	notification Notification,
+	deliver NotificationHandler,
+) error {
+	next := deliver
+
+	for index := len(c.streamInterceptors) - 1; index >= 0; index-- {
+		interceptor, inner := c.streamInterceptors[index], next
+
+		next = func(ctx context.Context, notification Notification) error {
+			return interceptor(ctx, notification, inner)
+		}
+	}
+
+	err := next(ctx, notification)
+	if err != nil && !errors.Is(err, ErrLiveNotificationDropped) {
+		return fmt.Errorf("%w: %w", ErrLiveNotificationDropped, err)
+	}
+
+	return err
+}
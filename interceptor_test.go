@@ -0,0 +1,207 @@
+package sdbc
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+	"gotest.tools/v3/assert"
+)
+
+func TestInterceptor(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mut     sync.Mutex
+		calls   []string
+		queries []any
+	)
+
+	record := func(name string) Interceptor {
+		return func(ctx context.Context, req Request, next Invoker) (Response, error) {
+			mut.Lock()
+			calls = append(calls, name+" "+req.Method)
+			mut.Unlock()
+
+			return next(ctx, req)
+		}
+	}
+
+	tagQuery := func(ctx context.Context, req Request, next Invoker) (Response, error) {
+		if req.Method == methodQuery {
+			query, _ := req.Params[0].(string)
+			req.Params = append([]any{"/* tenant */ " + query}, req.Params[1:]...)
+		}
+
+		res, err := next(ctx, req)
+		if err != nil {
+			return res, err
+		}
+
+		var version string
+
+		if err := cbor.Unmarshal(res.Result, &version); err == nil {
+			res.Result, err = cbor.Marshal("intercepted-" + version)
+		}
+
+		return res, err
+	}
+
+	client := newWebsocketTestClient(t, func(req request) (any, *responseError) {
+		if req.Method == methodQuery {
+			mut.Lock()
+			queries = append(queries, req.Params[0])
+			mut.Unlock()
+		}
+
+		return "surrealdb-2.1.0", nil
+	}, WithInterceptor(record("first")), WithInterceptor(record("second")), WithInterceptor(tagQuery))
+
+	res, err := client.Query(context.Background(), "SELECT * FROM user", nil)
+	assert.NilError(t, err)
+
+	var version string
+
+	assert.NilError(t, client.Unmarshal(res, &version))
+	assert.Equal(t, "intercepted-surrealdb-2.1.0", version)
+
+	mut.Lock()
+	defer mut.Unlock()
+
+	assert.DeepEqual(t, []string{"first query", "second query"}, calls)
+	assert.DeepEqual(t, []any{"/* tenant */ SELECT * FROM user"}, queries)
+}
+
+func TestInterceptorGuard(t *testing.T) {
+	t.Parallel()
+
+	errDenied := errors.New("denied")
+
+	var sent atomic.Int32
+
+	client := newWebsocketTestClient(t, func(_ request) (any, *responseError) {
+		sent.Add(1)
+
+		return nil, nil
+	}, WithInterceptor(func(ctx context.Context, req Request, next Invoker) (Response, error) {
+		if req.Method == methodDelete {
+			return Response{}, errDenied
+		}
+
+		return next(ctx, req)
+	}))
+
+	_, err := client.Delete(context.Background(), Table("user"))
+	assert.Check(t, errors.Is(err, errDenied))
+
+	_, err = client.Select(context.Background(), Table("user"))
+	assert.NilError(t, err)
+
+	assert.Equal(t, int32(1), sent.Load())
+}
+
+func TestStreamInterceptor(t *testing.T) {
+	t.Parallel()
+
+	marshal, unmarshal := newTestCodec(t, nil)
+	metrics := &fakeMetrics{}
+
+	type ctxKey struct{}
+
+	var liveIDs []string
+
+	client := &Client{
+		options: applyOptions([]Option{
+			WithMetrics(metrics),
+			WithTimeout(10 * time.Millisecond),
+			WithStreamInterceptor(func(ctx context.Context, notification Notification, next NotificationHandler) error {
+				assert.Equal(t, "live", ctx.Value(ctxKey{}))
+
+				liveIDs = append(liveIDs, notification.LiveID)
+
+				return next(ctx, notification)
+			}),
+			WithStreamInterceptor(func(ctx context.Context, notification Notification, next NotificationHandler) error {
+				var res liveResponse[string]
+
+				if err := unmarshal(notification.Result, &res); err != nil {
+					return err
+				}
+
+				switch res.Result {
+
+				case "skip":
+					return nil
+
+				case "fail":
+					return errors.New("failed")
+				}
+
+				res.Result = "intercepted-" + res.Result
+
+				result, err := marshal(res)
+				if err != nil {
+					return err
+				}
+
+				notification.Result = result
+
+				return next(ctx, notification)
+			}),
+		}),
+		marshal:     marshal,
+		unmarshal:   unmarshal,
+		liveQueries: newLiveQueries(),
+		connCtx:     context.Background(),
+	}
+
+	liveChan, _ := client.liveQueries.get("known_id", true)
+	client.liveQueries.setContext("known_id", context.WithValue(context.Background(), ctxKey{}, "live"))
+
+	notification := func(result string) *response {
+		raw, err := marshal(liveResponse[string]{ID: []byte("known_id"), Action: "CREATE", Result: result})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return &response{Result: raw}
+	}
+
+	go client.handleLiveQuery(notification("record"))
+
+	var res liveResponse[string]
+
+	assert.NilError(t, unmarshal(<-liveChan, &res))
+	assert.Equal(t, "intercepted-record", res.Result)
+
+	client.handleLiveQuery(notification("skip"))
+	client.handleLiveQuery(notification("fail"))
+
+	assert.DeepEqual(t, []string{"known_id", "known_id", "known_id"}, liveIDs)
+
+	metrics.mut.Lock()
+	defer metrics.mut.Unlock()
+
+	assert.Equal(t, 1, metrics.droppedNotifications)
+}
+
+func TestInterceptNotificationDropped(t *testing.T) {
+	t.Parallel()
+
+	client := &Client{options: applyOptions(nil)}
+
+	err := client.interceptNotification(context.Background(), Notification{}, func(context.Context, Notification) error {
+		return errors.New("failed")
+	})
+	assert.Check(t, errors.Is(err, ErrLiveNotificationDropped))
+	assert.ErrorContains(t, err, "failed")
+
+	err = client.interceptNotification(context.Background(), Notification{}, func(context.Context, Notification) error {
+		return nil
+	})
+	assert.NilError(t, err)
+}
@@ -0,0 +1,199 @@
+package surrealql
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrUnterminatedLiteral is returned by Lex for literals and
+// block comments that are not closed until the end of the query.
+var ErrUnterminatedLiteral = errors.New("unterminated literal")
+
+// TokenKind is the kind of a token.
+type TokenKind uint8
+
+const (
+	TokenOther     TokenKind = iota // anything else (keywords, operators, numbers, ...)
+	TokenSpace                      // whitespace
+	TokenComment                    // -- ..., // ..., # ... or /* ... */
+	TokenString                     // '...' or "..."
+	TokenIdent                      // `...` or ⟨...⟩
+	TokenParam                      // $name
+	TokenSemicolon                  // ;
+	TokenOpen                       // ( [ {
+	TokenClose                      // ) ] }
+)
+
+// Token is a part of a query, referencing the input by its byte offsets.
+type Token struct {
+	Kind  TokenKind
+	Start int
+	End   int
+}
+
+// lexer splits a SurrealQL query into tokens. It is not a complete
+// SurrealQL lexer, but recognizes everything that matters to tell
+// statements, literals, comments and parameters apart.
+type lexer struct {
+	input  string
+	pos    int
+	tokens []Token
+}
+
+// Lex splits the query into tokens. Concatenating all tokens results in the query.
+func Lex(query string) ([]Token, error) {
+	lex := &lexer{input: query}
+
+	for lex.pos < len(lex.input) {
+		start := lex.pos
+
+		kind, err := lex.next()
+		if err != nil {
+			return nil, fmt.Errorf("%w at position %d", err, start)
+		}
+
+		// merge adjacent tokens of the same unspecific kind
+		if last := len(lex.tokens) - 1; last >= 0 && lex.tokens[last].Kind == kind && kind == TokenOther {
+			lex.tokens[last].End = lex.pos
+
+			continue
+		}
+
+		lex.tokens = append(lex.tokens, Token{Kind: kind, Start: start, End: lex.pos})
+	}
+
+	return lex.tokens, nil
+}
+
+func (l *lexer) rest() string {
+	return l.input[l.pos:]
+}
+
+func (l *lexer) next() (TokenKind, error) {
+	char, size := utf8.DecodeRuneInString(l.rest())
+
+	switch {
+
+	case char == ' ' || char == '\t' || char == '\n' || char == '\r':
+		l.pos += size
+
+		return TokenSpace, nil
+
+	case strings.HasPrefix(l.rest(), "--") || strings.HasPrefix(l.rest(), "//") || char == '#':
+		l.skipUntil("\n", false)
+
+		return TokenComment, nil
+
+	case strings.HasPrefix(l.rest(), "/*"):
+		l.pos += len("/*")
+
+		if !l.skipUntil("*/", true) {
+			return TokenComment, ErrUnterminatedLiteral
+		}
+
+		return TokenComment, nil
+
+	case char == '\'' || char == '"':
+		return TokenString, l.skipQuoted(char)
+
+	case char == '`':
+		return TokenIdent, l.skipQuoted(char)
+
+	case char == '⟨':
+		return TokenIdent, l.skipQuoted('⟩')
+
+	case char == '$':
+		l.pos += size
+		l.skipIdentChars()
+
+		return TokenParam, nil
+
+	case char == ';':
+		l.pos += size
+
+		return TokenSemicolon, nil
+
+	case char == '(' || char == '[' || char == '{':
+		l.pos += size
+
+		return TokenOpen, nil
+
+	case char == ')' || char == ']' || char == '}':
+		l.pos += size
+
+		return TokenClose, nil
+
+	case IsIdentChar(char):
+		l.skipIdentChars()
+
+		return TokenOther, nil
+
+	default:
+		l.pos += size
+
+		return TokenOther, nil
+	}
+}
+
+// skipUntil moves the position behind the next occurrence of the given
+// delimiter (if inclusive) or right before it. It reports false if the
+// delimiter is missing, in which case the position is moved to the end.
+func (l *lexer) skipUntil(delimiter string, inclusive bool) bool {
+	index := strings.Index(l.rest(), delimiter)
+	if index < 0 {
+		l.pos = len(l.input)
+
+		return false
+	}
+
+	l.pos += index
+
+	if inclusive {
+		l.pos += len(delimiter)
+	}
+
+	return true
+}
+
+// skipQuoted moves the position behind the literal starting at the current
+// position and ending with the closing character. Within the literal, any
+// character can be escaped with a preceding backslash.
+func (l *lexer) skipQuoted(closing rune) error {
+	_, size := utf8.DecodeRuneInString(l.rest())
+	l.pos += size
+
+	for l.pos < len(l.input) {
+		char, size := utf8.DecodeRuneInString(l.rest())
+		l.pos += size
+
+		switch char {
+
+		case '\\':
+			_, size := utf8.DecodeRuneInString(l.rest())
+			l.pos += size
+
+		case closing:
+			return nil
+		}
+	}
+
+	return ErrUnterminatedLiteral
+}
+
+func (l *lexer) skipIdentChars() {
+	for l.pos < len(l.input) {
+		char, size := utf8.DecodeRuneInString(l.rest())
+		if !IsIdentChar(char) {
+			return
+		}
+
+		l.pos += size
+	}
+}
+
+// IsIdentChar reports whether the character may be part of a plain identifier.
+func IsIdentChar(char rune) bool {
+	return char == '_' || (char >= 'a' && char <= 'z') || (char >= 'A' && char <= 'Z') || (char >= '0' && char <= '9')
+}
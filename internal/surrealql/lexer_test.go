@@ -0,0 +1,54 @@
+package surrealql
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestLex(t *testing.T) {
+	t.Parallel()
+
+	query := "SELECT * FROM ⟨a;b⟩ WHERE x = 'it\\'s;' -- c;\n/* ; */ AND y = $id;"
+
+	tokens, err := Lex(query)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		builder strings.Builder
+		kinds   = make(map[TokenKind][]string)
+	)
+
+	for _, tok := range tokens {
+		builder.WriteString(query[tok.Start:tok.End])
+		kinds[tok.Kind] = append(kinds[tok.Kind], query[tok.Start:tok.End])
+	}
+
+	assert.Equal(t, query, builder.String())
+	assert.DeepEqual(t, []string{"⟨a;b⟩"}, kinds[TokenIdent])
+	assert.DeepEqual(t, []string{"'it\\'s;'"}, kinds[TokenString])
+	assert.DeepEqual(t, []string{"-- c;", "/* ; */"}, kinds[TokenComment])
+	assert.DeepEqual(t, []string{"$id"}, kinds[TokenParam])
+	assert.DeepEqual(t, []string{";"}, kinds[TokenSemicolon])
+}
+
+func TestLexErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []string{
+		"SELECT * FROM 'abc",
+		`SELECT * FROM "abc\"`,
+		"SELECT * FROM `abc",
+		"SELECT * FROM ⟨abc",
+		"SELECT * FROM abc /* comment",
+	}
+
+	for _, query := range tests {
+		_, err := Lex(query)
+		assert.Check(t, errors.Is(err, ErrUnterminatedLiteral), query)
+	}
+}
@@ -0,0 +1,95 @@
+// Package surrealql contains the SurrealQL helpers shared by the packages
+// of this module: escaping of identifiers, formatting of durations and a
+// lexer to tell statements, literals, comments and parameters apart.
+package surrealql
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//
+// -- ESCAPING
+//
+
+var regexIdent = regexp.MustCompile(`^[A-Za-z0-9_]*[A-Za-z_][A-Za-z0-9_]*$`)
+
+// IsIdent reports whether the given string is a plain identifier
+// (letters, digits and underscores, but not only digits).
+func IsIdent(str string) bool {
+	return regexIdent.MatchString(str)
+}
+
+// EscapeIdent escapes the given identifier with backticks,
+// if it is not a plain identifier (see IsIdent).
+func EscapeIdent(ident string) string {
+	if IsIdent(ident) {
+		return ident
+	}
+
+	return "`" + strings.ReplaceAll(strings.ReplaceAll(ident, `\`, `\\`), "`", "\\`") + "`"
+}
+
+//
+// -- DURATIONS
+//
+
+const (
+	Day  = 24 * time.Hour
+	Week = 7 * Day
+	Year = 365 * Day
+)
+
+// DurationUnit is a unit of the SurrealQL duration format.
+type DurationUnit struct {
+	Name  string
+	Value time.Duration
+}
+
+// DurationUnits contains the units of the SurrealQL duration format.
+// The order matters, so that "ms" is matched before "m".
+var DurationUnits = []DurationUnit{
+	{"ns", time.Nanosecond},
+	{"us", time.Microsecond},
+	{"µs", time.Microsecond},
+	{"ms", time.Millisecond},
+	{"s", time.Second},
+	{"m", time.Minute},
+	{"h", time.Hour},
+	{"d", Day},
+	{"w", Week},
+	{"y", Year},
+}
+
+// FormatDuration formats the given duration in the SurrealQL format,
+// e.g. 1h30m or 1s500ms.
+func FormatDuration(dur time.Duration) string {
+	if dur == 0 {
+		return "0ns"
+	}
+
+	var builder strings.Builder
+
+	if dur < 0 {
+		builder.WriteByte('-')
+
+		dur = -dur
+	}
+
+	for index := len(DurationUnits) - 1; index >= 0; index-- {
+		unit := DurationUnits[index]
+
+		if unit.Name == "us" || dur < unit.Value {
+			continue // µs is used instead of us
+		}
+
+		builder.WriteString(strconv.FormatInt(int64(dur/unit.Value), 10))
+		builder.WriteString(unit.Name)
+
+		dur %= unit.Value
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,35 @@
+package surrealql
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestEscapeIdent(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "person", EscapeIdent("person"))
+	assert.Equal(t, "`some table`", EscapeIdent("some table"))
+	assert.Equal(t, "`123`", EscapeIdent("123"))
+	assert.Equal(t, "`a\\`b`", EscapeIdent("a`b"))
+	assert.Equal(t, "`a\\\\b`", EscapeIdent(`a\b`))
+}
+
+func TestFormatDuration(t *testing.T) {
+	t.Parallel()
+
+	tests := map[time.Duration]string{
+		0:                       "0ns",
+		5 * time.Second:         "5s",
+		1500 * time.Millisecond: "1s500ms",
+		3 * time.Microsecond:    "3µs",
+		-90 * time.Minute:       "-1h30m",
+		8 * Day:                 "1w1d",
+	}
+
+	for dur, want := range tests {
+		assert.Equal(t, want, FormatDuration(dur))
+	}
+}
@@ -0,0 +1,258 @@
+package sdbc
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-surreal/sdbc/internal/surrealql"
+)
+
+// lex splits the query into tokens (see surrealql.Lex).
+// Errors are wrapped with ErrInvalidQuery.
+func lex(query string) ([]surrealql.Token, error) {
+	tokens, err := surrealql.Lex(query)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidQuery, err)
+	}
+
+	return tokens, nil
+}
+
+// countStatements returns the number of non-empty statements of the query.
+// Semicolons within blocks, literals and comments are not taken into account.
+func countStatements(tokens []surrealql.Token) int {
+	var (
+		count   int
+		depth   int
+		pending bool // whether the current statement has content
+	)
+
+	for _, tok := range tokens {
+		switch tok.Kind {
+
+		case surrealql.TokenSpace, surrealql.TokenComment:
+			continue
+
+		case surrealql.TokenSemicolon:
+			if depth == 0 {
+				if pending {
+					count++
+				}
+
+				pending = false
+
+				continue
+			}
+
+		case surrealql.TokenOpen:
+			depth++
+
+		case surrealql.TokenClose:
+			depth = max(depth-1, 0)
+
+		default:
+		}
+
+		pending = true
+	}
+
+	if pending {
+		count++
+	}
+
+	return count
+}
+
+// splitStatements splits the query into its non-empty statements (without the
+// separating semicolons). Semicolons within blocks, literals and comments are
+// not taken into account. Comments between statements are dropped.
+func splitStatements(query string) ([]string, error) {
+	tokens, err := lex(query)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		statements []string
+		depth      int
+		start      = -1 // start of the current statement
+		end        int  // end of the current statement (without trailing space)
+	)
+
+	for _, tok := range tokens {
+		switch tok.Kind {
+
+		case surrealql.TokenSpace, surrealql.TokenComment:
+			continue
+
+		case surrealql.TokenSemicolon:
+			if depth == 0 {
+				if start >= 0 {
+					statements = append(statements, query[start:end])
+				}
+
+				start = -1
+
+				continue
+			}
+
+		case surrealql.TokenOpen:
+			depth++
+
+		case surrealql.TokenClose:
+			depth = max(depth-1, 0)
+
+		default:
+		}
+
+		if start < 0 {
+			start = tok.Start
+		}
+
+		end = tok.End
+	}
+
+	if start >= 0 {
+		statements = append(statements, query[start:end])
+	}
+
+	return statements, nil
+}
+
+// requireSingleStatement returns ErrMultipleStatements if the query
+// consists of more than one statement. A trailing semicolon is allowed.
+func requireSingleStatement(query string) error {
+	tokens, err := lex(query)
+	if err != nil {
+		return err
+	}
+
+	if countStatements(tokens) > 1 {
+		return ErrMultipleStatements
+	}
+
+	return nil
+}
+
+// conditionKeywords contains the (upper case) keywords of the statements
+// that must not be part of a condition, because they modify data, the schema
+// or the session. Only SELECT and the control flow statements are allowed.
+var conditionKeywords = map[string]bool{
+	"ACCESS": true, "ALTER": true, "BEGIN": true, "CANCEL": true, "COMMIT": true,
+	"CREATE": true, "DEFINE": true, "DELETE": true, "INSERT": true, "KILL": true,
+	"LIVE": true, "OPTION": true, "REBUILD": true, "RELATE": true, "REMOVE": true,
+	"SLEEP": true, "UPDATE": true, "UPSERT": true, "USE": true,
+}
+
+// requireCondition returns an error if the condition could escape the
+// clause it is embedded in: ErrMultipleStatements for a semicolon outside of
+// brackets and ErrInvalidQuery for unbalanced brackets or line comments,
+// which would swallow the clauses that follow. Statements that modify data,
+// the schema or the session (see conditionKeywords) are rejected with
+// ErrInvalidQuery at any depth, e.g. "true OR (DELETE user)". Fields with
+// such a name must be escaped, e.g. "`update` = true".
+func requireCondition(cond string) error {
+	tokens, err := lex(cond)
+	if err != nil {
+		return err
+	}
+
+	var depth int
+
+	for _, tok := range tokens {
+		switch tok.Kind {
+
+		case surrealql.TokenSemicolon:
+			if depth == 0 {
+				return ErrMultipleStatements
+			}
+
+		case surrealql.TokenComment:
+			if !strings.HasPrefix(cond[tok.Start:tok.End], "/*") {
+				return fmt.Errorf("%w: line comment in condition", ErrInvalidQuery)
+			}
+
+		case surrealql.TokenOpen:
+			depth++
+
+		case surrealql.TokenClose:
+			depth--
+
+			if depth < 0 {
+				return fmt.Errorf("%w: unbalanced brackets in condition", ErrInvalidQuery)
+			}
+
+		case surrealql.TokenOther:
+			if keyword, ok := statementKeyword(cond[tok.Start:tok.End]); ok {
+				return fmt.Errorf("%w: %s statement in condition", ErrInvalidQuery, keyword)
+			}
+
+		default:
+		}
+	}
+
+	if depth != 0 {
+		return fmt.Errorf("%w: unbalanced brackets in condition", ErrInvalidQuery)
+	}
+
+	return nil
+}
+
+// statementKeyword returns the first word of the given text that is one of
+// the conditionKeywords. Words that are part of a path (a.update), a record ID
+// (delete:1) or a function name (array::insert) are not taken into account.
+func statementKeyword(text string) (string, bool) {
+	for start := 0; start < len(text); {
+		if !surrealql.IsIdentChar(rune(text[start])) {
+			start++
+
+			continue
+		}
+
+		end := start
+
+		for end < len(text) && surrealql.IsIdentChar(rune(text[end])) {
+			end++
+		}
+
+		word := strings.ToUpper(text[start:end])
+		qualified := (start > 0 && (text[start-1] == '.' || text[start-1] == ':')) ||
+			(end < len(text) && text[end] == ':')
+
+		if !qualified && conditionKeywords[word] {
+			return word, true
+		}
+
+		start = end
+	}
+
+	return "", false
+}
+
+// renameParams replaces the parameters of the query according to the given
+// names (without the leading $). Parameters within literals or comments and
+// parameters that only share a prefix with one of the names are kept as is.
+func renameParams(query string, names map[string]string) (string, error) {
+	tokens, err := lex(query)
+	if err != nil {
+		return "", err
+	}
+
+	var builder strings.Builder
+
+	builder.Grow(len(query))
+
+	for _, tok := range tokens {
+		text := query[tok.Start:tok.End]
+
+		if tok.Kind == surrealql.TokenParam {
+			if renamed, ok := names[text[1:]]; ok {
+				text = "$" + renamed
+			}
+		}
+
+		builder.WriteString(text)
+	}
+
+	return builder.String(), nil
+}
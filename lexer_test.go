@@ -0,0 +1,170 @@
+package sdbc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestLexErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []string{
+		"SELECT * FROM 'abc",
+		`SELECT * FROM "abc\"`,
+		"SELECT * FROM `abc",
+		"SELECT * FROM ⟨abc",
+		"SELECT * FROM abc /* comment",
+	}
+
+	for _, query := range tests {
+		_, err := lex(query)
+		assert.Check(t, errors.Is(err, ErrInvalidQuery), query)
+	}
+}
+
+func TestRequireSingleStatement(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in    string
+		valid bool
+	}{
+		{in: "SELECT * FROM some", valid: true},
+		{in: "SELECT * FROM some;", valid: true},
+		{in: "SELECT * FROM some; ;  -- done", valid: true},
+		{in: "SELECT * FROM some WHERE name = 'a;b'", valid: true},
+		{in: "SELECT * FROM some WHERE name = \"a;b\";", valid: true},
+		{in: "SELECT * FROM ⟨a;b⟩", valid: true},
+		{in: "SELECT * FROM `a;b`", valid: true},
+		{in: "SELECT * FROM some -- ; DELETE some", valid: true},
+		{in: "SELECT * FROM some /* ; DELETE some */", valid: true},
+		{in: "SELECT * FROM some WHERE (SELECT VALUE x FROM { LET $a = 1; RETURN $a; })", valid: true},
+		{in: "SELECT * FROM some; DELETE some", valid: false},
+		{in: "SELECT * FROM some;DELETE some;", valid: false},
+		{in: "SELECT * FROM some # comment\n; DELETE some", valid: false},
+	}
+
+	for _, test := range tests {
+		err := requireSingleStatement(test.in)
+
+		if test.valid {
+			assert.NilError(t, err, test.in)
+		} else {
+			assert.Check(t, errors.Is(err, ErrMultipleStatements), test.in)
+		}
+	}
+}
+
+func TestSplitStatements(t *testing.T) {
+	t.Parallel()
+
+	statements, err := splitStatements(`
+		-- the user table
+		DEFINE TABLE user;
+		DEFINE FIELD name ON user DEFAULT 'a;b'; ;
+		DEFINE EVENT e ON user WHEN true THEN { CREATE log; RETURN NONE; } /* done */
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.DeepEqual(t, []string{
+		"DEFINE TABLE user",
+		"DEFINE FIELD name ON user DEFAULT 'a;b'",
+		"DEFINE EVENT e ON user WHEN true THEN { CREATE log; RETURN NONE; }",
+	}, statements)
+
+	_, err = splitStatements("DEFINE TABLE 'user")
+	assert.Check(t, errors.Is(err, ErrInvalidQuery))
+}
+
+func TestRequireCondition(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in  string
+		err error
+	}{
+		{in: "age >= $min", err: nil},
+		{in: "name = 'a;b' AND tag = \"(\"", err: nil},
+		{in: "count(SELECT * FROM { LET $a = 1; RETURN $a; }) > 0", err: nil},
+		{in: "age > 18 /* adults */", err: nil},
+		{in: "true; DELETE user", err: ErrMultipleStatements},
+		{in: "true;", err: ErrMultipleStatements},
+		{in: "true) OR (true", err: ErrInvalidQuery},
+		{in: "(true", err: ErrInvalidQuery},
+		{in: "true -- ", err: ErrInvalidQuery},
+		{in: "true # ", err: ErrInvalidQuery},
+		{in: "name = 'open", err: ErrInvalidQuery},
+		{in: "true OR (DELETE user)", err: ErrInvalidQuery},
+		{in: "true OR [{ update user SET admin = true }]", err: ErrInvalidQuery},
+		{in: "id IN (SELECT id FROM (CREATE user))", err: ErrInvalidQuery},
+		{in: "age>sleep 1s", err: ErrInvalidQuery},
+		{in: "id IN (SELECT VALUE id FROM user WHERE a.update = true)", err: nil},
+		{in: "id != delete:1 AND array::insert(tags, 'a', 0) != []", err: nil},
+		{in: "`update` = true AND name = 'DELETE'", err: nil},
+		{in: "created_at < time::now()", err: nil},
+	}
+
+	for _, test := range tests {
+		err := requireCondition(test.in)
+
+		if test.err == nil {
+			assert.NilError(t, err, test.in)
+		} else {
+			assert.Check(t, errors.Is(err, test.err), test.in)
+		}
+	}
+}
+
+func TestRenameParams(t *testing.T) {
+	t.Parallel()
+
+	names := map[string]string{
+		"id":   "x_id",
+		"name": "x_name",
+	}
+
+	tests := []struct {
+		in       string
+		expected string
+	}{
+		{
+			in:       "SELECT * FROM some WHERE id = $id AND idx = $idx",
+			expected: "SELECT * FROM some WHERE id = $x_id AND idx = $idx",
+		},
+		{
+			in:       "SELECT * FROM some WHERE name = $name OR name = '$name'",
+			expected: "SELECT * FROM some WHERE name = $x_name OR name = '$name'",
+		},
+		{
+			in:       "SELECT * FROM some WHERE id IN [$id,$id] -- $id",
+			expected: "SELECT * FROM some WHERE id IN [$x_id,$x_id] -- $id",
+		},
+		{
+			in:       "SELECT * FROM some WHERE `$id` = $id_2",
+			expected: "SELECT * FROM some WHERE `$id` = $id_2",
+		},
+	}
+
+	for _, test := range tests {
+		out, err := renameParams(test.in, names)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, test.expected, out)
+	}
+}
+
+func TestLiveMultipleStatements(t *testing.T) {
+	t.Parallel()
+
+	client := &Client{}
+
+	_, err := client.Live(context.Background(), "SELECT * FROM some; DELETE some;", nil)
+	assert.Check(t, errors.Is(err, ErrMultipleStatements))
+}
@@ -5,7 +5,10 @@ import (
 	"crypto/rand"
 	"fmt"
 	"math/big"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/coder/websocket"
 	"github.com/fxamacker/cbor/v2"
@@ -15,7 +18,9 @@ const (
 	methodUse     = "use"
 	methodVersion = "version"
 
-	methodSignIn = "signin"
+	methodSignIn       = "signin"
+	methodSignUp       = "signup"
+	methodAuthenticate = "authenticate"
 
 	methodCreate = "create"
 	methodInsert = "insert"
@@ -32,6 +37,7 @@ const (
 	methodQuery = "query"
 
 	livePrefix = "live"
+	methodLive = "live"
 	methodKill = "kill"
 
 	methodLet     = "let"
@@ -40,6 +46,7 @@ const (
 	methodGraphQL = "graphql"
 
 	randomVariablePrefixLength = 32
+	liveParamPrefix            = "sdbc_live_"
 
 	versionPrefix = "surrealdb-"
 )
@@ -103,7 +110,13 @@ func (c *Client) signIn(ctx context.Context, username, password string) error {
 		return fmt.Errorf("failed to sign in: %w", err)
 	}
 
-	c.token = string(res)
+	var token string
+
+	if err := cbor.Unmarshal(res, &token); err != nil {
+		return fmt.Errorf("failed to unmarshal token: %w", err)
+	}
+
+	c.token = token
 
 	return nil
 }
@@ -113,14 +126,17 @@ func (c *Client) signIn(ctx context.Context, username, password string) error {
 //
 
 // Create a record with a random or specified ID.
-func (c *Client) Create(ctx context.Context, id RecordID, data any) ([]byte, error) {
+// The options support the return mode and the timeout (see CRUDOptions).
+func (c *Client) Create(ctx context.Context, id RecordID, data any, opts ...CRUDOptions) ([]byte, error) {
+	params, err := c.crudParams(methodCreate, opts, id, data)
+	if err != nil {
+		return nil, err
+	}
+
 	res, err := c.send(ctx,
 		request{
 			Method: methodCreate,
-			Params: []any{
-				id,
-				data,
-			},
+			Params: params,
 		},
 	)
 	if err != nil {
@@ -131,8 +147,14 @@ func (c *Client) Create(ctx context.Context, id RecordID, data any) ([]byte, err
 }
 
 // Insert one or multiple records in a table.
-// TODO: allow for fixed IDs.
-func (c *Client) Insert(ctx context.Context, table string, data []any) ([]byte, error) {
+// The data is either a single record or a slice of records. Records may carry
+// their own "id" field (e.g. a *ID or the identifier part only), otherwise
+// a random ID is generated. Use InsertWithOptions to handle existing records.
+func (c *Client) Insert(ctx context.Context, table Table, data any) ([]byte, error) {
+	if err := table.Validate(); err != nil {
+		return nil, err
+	}
+
 	res, err := c.send(ctx,
 		request{
 			Method: methodInsert,
@@ -149,16 +171,99 @@ func (c *Client) Insert(ctx context.Context, table string, data []any) ([]byte,
 	return res, nil
 }
 
+// InsertOptions define how InsertWithOptions handles records that already exist.
+type InsertOptions struct {
+	// Ignore skips records whose ID already exists (INSERT IGNORE).
+	Ignore bool
+
+	// OnDuplicate updates the given fields of records whose ID already exists
+	// (ON DUPLICATE KEY UPDATE). Keys are field paths (e.g. "name" or "stats.count"),
+	// values are the values to set. It must not be used together with Ignore.
+	OnDuplicate map[string]any
+}
+
+// InsertWithOptions inserts one or multiple records in a table like Insert,
+// but allows to define how records that already exist are handled.
+// It returns the resulting records one by one, so that each of them can be
+// decoded separately. Records that are ignored are not part of the result.
+func (c *Client) InsertWithOptions(ctx context.Context, table Table, data any, opts InsertOptions) ([][]byte, error) {
+	if err := table.Validate(); err != nil {
+		return nil, err
+	}
+
+	if opts.Ignore && len(opts.OnDuplicate) > 0 {
+		return nil, ErrConflictingInsertOptions
+	}
+
+	query, vars := buildInsertQuery(table, data, opts)
+
+	res, err := c.queryRecords(ctx, query, vars)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert records: %w", err)
+	}
+
+	return res, nil
+}
+
+func buildInsertQuery(table Table, data any, opts InsertOptions) (string, map[string]any) {
+	vars := map[string]any{
+		"data": data,
+	}
+
+	var query strings.Builder
+
+	query.WriteString("INSERT ")
+
+	if opts.Ignore {
+		query.WriteString("IGNORE ")
+	}
+
+	query.WriteString("INTO " + table.String() + " $data")
+
+	if len(opts.OnDuplicate) > 0 {
+		fields := make([]string, 0, len(opts.OnDuplicate))
+		for field := range opts.OnDuplicate {
+			fields = append(fields, field)
+		}
+
+		slices.Sort(fields)
+
+		query.WriteString(" ON DUPLICATE KEY UPDATE ")
+
+		for index, field := range fields {
+			if index > 0 {
+				query.WriteString(", ")
+			}
+
+			name := "update_" + strconv.Itoa(index)
+			vars[name] = opts.OnDuplicate[field]
+
+			query.WriteString(escapeFieldPath(field) + " = $" + name)
+		}
+	}
+
+	query.WriteString(";")
+
+	return query.String(), vars
+}
+
 // Update modifies either all records in a table or a single
 // record with specified data if the record already exists.
-func (c *Client) Update(ctx context.Context, id *ID, data any) ([]byte, error) {
+// The options support all but fetch (see CRUDOptions).
+func (c *Client) Update(ctx context.Context, thing Thing, data any, opts ...CRUDOptions) ([]byte, error) {
+	if err := validateThing(thing); err != nil {
+		return nil, err
+	}
+
+	params, err := c.crudParams(methodUpdate, opts, thing, data)
+	if err != nil {
+		return nil, err
+	}
+
 	res, err := c.send(ctx,
 		request{
 			Method: methodUpdate,
-			Params: []any{
-				id,
-				data,
-			},
+			Params: params,
 		},
 	)
 	if err != nil {
@@ -169,15 +274,22 @@ func (c *Client) Update(ctx context.Context, id *ID, data any) ([]byte, error) {
 }
 
 // Upsert replaces either all records in a table or a single record with specified data.
-// Note: Only supported by SurrealDB v2.0.0 and later.
-func (c *Client) Upsert(ctx context.Context, id RecordID, data any) ([]byte, error) {
+// It requires SurrealDB v2.0.0 or later, otherwise ErrUnsupportedByServer is returned.
+// The options support all but fetch (see CRUDOptions).
+func (c *Client) Upsert(ctx context.Context, id RecordID, data any, opts ...CRUDOptions) ([]byte, error) {
+	if err := c.requireVersion("upsert", version2); err != nil {
+		return nil, err
+	}
+
+	params, err := c.crudParams(methodUpsert, opts, id, data)
+	if err != nil {
+		return nil, err
+	}
+
 	res, err := c.send(ctx,
 		request{
 			Method: methodUpsert,
-			Params: []any{
-				id,
-				data,
-			},
+			Params: params,
 		},
 	)
 	if err != nil {
@@ -188,8 +300,11 @@ func (c *Client) Upsert(ctx context.Context, id RecordID, data any) ([]byte, err
 }
 
 // Merge specified data into either all records in a table or a single record.
-// TODO: support "all" records.
-func (c *Client) Merge(ctx context.Context, thing *ID, data any) ([]byte, error) {
+func (c *Client) Merge(ctx context.Context, thing Thing, data any) ([]byte, error) {
+	if err := validateThing(thing); err != nil {
+		return nil, err
+	}
+
 	res, err := c.send(ctx,
 		request{
 			Method: methodMerge,
@@ -208,7 +323,11 @@ func (c *Client) Merge(ctx context.Context, thing *ID, data any) ([]byte, error)
 
 // Patch either all records in a table or a single record with specified patches.
 // see: https://jsonpatch.com/
-func (c *Client) Patch(ctx context.Context, thing *ID, patches []Patch, diff bool) ([]byte, error) {
+func (c *Client) Patch(ctx context.Context, thing Thing, patches []Patch, diff bool) ([]byte, error) {
+	if err := validateThing(thing); err != nil {
+		return nil, err
+	}
+
 	res, err := c.send(ctx,
 		request{
 			Method: methodPatch,
@@ -227,13 +346,21 @@ func (c *Client) Patch(ctx context.Context, thing *ID, patches []Patch, diff boo
 }
 
 // Delete either all records in a table or a single record.
-func (c *Client) Delete(ctx context.Context, id *ID) ([]byte, error) {
+// The options support the return mode, the condition and the timeout (see CRUDOptions).
+func (c *Client) Delete(ctx context.Context, thing Thing, opts ...CRUDOptions) ([]byte, error) {
+	if err := validateThing(thing); err != nil {
+		return nil, err
+	}
+
+	params, err := c.crudParams(methodDelete, opts, thing)
+	if err != nil {
+		return nil, err
+	}
+
 	res, err := c.send(ctx,
 		request{
 			Method: methodDelete,
-			Params: []any{
-				id,
-			},
+			Params: params,
 		},
 	)
 	if err != nil {
@@ -244,13 +371,21 @@ func (c *Client) Delete(ctx context.Context, id *ID) ([]byte, error) {
 }
 
 // Select either all records in a table or a single record.
-func (c *Client) Select(ctx context.Context, id *ID) ([]byte, error) {
+// The options support the condition, the timeout and fetch (see CRUDOptions).
+func (c *Client) Select(ctx context.Context, thing Thing, opts ...CRUDOptions) ([]byte, error) {
+	if err := validateThing(thing); err != nil {
+		return nil, err
+	}
+
+	params, err := c.crudParams(methodSelect, opts, thing)
+	if err != nil {
+		return nil, err
+	}
+
 	res, err := c.send(ctx,
 		request{
 			Method: methodSelect,
-			Params: []any{
-				id,
-			},
+			Params: params,
 		},
 	)
 	if err != nil {
@@ -260,6 +395,180 @@ func (c *Client) Select(ctx context.Context, id *ID) ([]byte, error) {
 	return res, nil
 }
 
+//
+// -- CRUD OPTIONS
+//
+
+// DataMode defines how the data of a request is applied to the records.
+type DataMode string
+
+const (
+	// DataContent replaces the records with the given data (default).
+	DataContent DataMode = "content"
+
+	// DataMerge merges the given data into the records.
+	DataMerge DataMode = "merge"
+
+	// DataPatch applies the given JSON patches ([]Patch) to the records.
+	DataPatch DataMode = "patch"
+
+	// DataReplace replaces the whole content of the records with the given data.
+	// Fields that are not part of the data are removed, the record IDs are kept.
+	DataReplace DataMode = "replace"
+)
+
+// ReturnMode defines what a request returns for each affected record.
+type ReturnMode string
+
+const (
+	// ReturnNone returns nothing.
+	ReturnNone ReturnMode = "none"
+
+	// ReturnBefore returns the records before the change.
+	ReturnBefore ReturnMode = "before"
+
+	// ReturnAfter returns the records after the change (default).
+	ReturnAfter ReturnMode = "after"
+
+	// ReturnDiff returns the changes as JSON patches.
+	ReturnDiff ReturnMode = "diff"
+)
+
+// CRUDOptions are the extended params of the methods Create, Update, Upsert,
+// Delete and Select. They are sent along with the request and require
+// SurrealDB v2.2.0 or later, otherwise ErrUnsupportedByServer is returned.
+// Not every option is supported by every method, in which case
+// ErrOptionNotSupported is returned.
+type CRUDOptions struct {
+	// Data defines how the data is applied (update and upsert).
+	// If empty, the default of the server (DataContent) is used.
+	Data DataMode
+
+	// Return defines the returned values (create, update, upsert and delete).
+	// If empty, the default of the server is used.
+	Return ReturnMode
+
+	// Where is a SurrealQL condition (update, upsert, delete and select),
+	// e.g. "age >= $min_age". The variables can be passed with Vars.
+	// It must be a single expression: semicolons outside of brackets,
+	// unbalanced brackets, line comments and statements that modify data,
+	// the schema or the session (even within subqueries) are rejected.
+	Where string
+
+	// Vars contains the variables used within the condition.
+	Vars map[string]any
+
+	// Timeout cancels the request on the server if it takes longer.
+	// If zero, the request is not limited.
+	Timeout time.Duration
+
+	// Fetch contains the record link fields to fetch (select only).
+	Fetch []string
+}
+
+// crudOptions are the CRUDOptions as sent to the server.
+type crudOptions struct {
+	DataExpr DataMode       `cbor:"data_expr,omitempty"`
+	Output   ReturnMode     `cbor:"output,omitempty"`
+	Cond     string         `cbor:"cond,omitempty"`
+	Vars     map[string]any `cbor:"vars,omitempty"`
+	Timeout  *Duration      `cbor:"timeout,omitempty"`
+	Fetch    []string       `cbor:"fetch,omitempty"`
+}
+
+// crudParams returns the given params of the CRUD method, followed by the
+// options (if any). The options are validated against the given method.
+func (c *Client) crudParams(method string, opts []CRUDOptions, params ...any) ([]any, error) {
+	if len(opts) == 0 {
+		return params, nil
+	}
+
+	if len(opts) > 1 {
+		return nil, fmt.Errorf("%w: at most one options value is allowed", ErrOptionNotSupported)
+	}
+
+	if err := c.requireVersion(method+" options", version22); err != nil {
+		return nil, err
+	}
+
+	encoded, err := opts[0].encode(method)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(params, encoded), nil
+}
+
+// encode validates the options for the given method and converts them
+// into the extended params of the request.
+func (o CRUDOptions) encode(method string) (crudOptions, error) {
+	if err := o.supportedBy(method); err != nil {
+		return crudOptions{}, err
+	}
+
+	switch o.Data {
+
+	case "", DataContent, DataMerge, DataPatch, DataReplace:
+
+	default:
+		return crudOptions{}, fmt.Errorf("%w: unknown data mode %q", ErrOptionNotSupported, o.Data)
+	}
+
+	switch o.Return {
+
+	case "", ReturnNone, ReturnBefore, ReturnAfter, ReturnDiff:
+
+	default:
+		return crudOptions{}, fmt.Errorf("%w: unknown return mode %q", ErrOptionNotSupported, o.Return)
+	}
+
+	if o.Where != "" {
+		if err := requireCondition(o.Where); err != nil {
+			return crudOptions{}, fmt.Errorf("invalid where condition: %w", err)
+		}
+	}
+
+	encoded := crudOptions{
+		DataExpr: o.Data,
+		Output:   o.Return,
+		Cond:     o.Where,
+		Vars:     o.Vars,
+		Fetch:    o.Fetch,
+	}
+
+	if o.Timeout > 0 {
+		encoded.Timeout = &Duration{Duration: o.Timeout}
+	}
+
+	return encoded, nil
+}
+
+// supportedBy returns ErrOptionNotSupported if one
+// of the options is set, but not supported by the method.
+func (o CRUDOptions) supportedBy(method string) error {
+	var option string
+
+	switch {
+
+	case o.Data != "" && method != methodUpdate && method != methodUpsert:
+		option = "data mode"
+
+	case o.Return != "" && method == methodSelect:
+		option = "return mode"
+
+	case o.Where != "" && method == methodCreate:
+		option = "where"
+
+	case len(o.Fetch) > 0 && method != methodSelect:
+		option = "fetch"
+
+	default:
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s does not support the option %s", ErrOptionNotSupported, method, option)
+}
+
 //
 // -- QUERY
 //
@@ -294,6 +603,11 @@ func (c *Client) Query(ctx context.Context, query string, vars map[string]any) (
 // this method. This way, the live query can be filtered by said params.
 // Please note that this is a workaround and may not work as expected in all cases.
 //
+// The params are removed when the live query is killed or the client is closed.
+// To remove params left behind by a crashed process, use WithLiveParamSweep.
+// With WithLiveSessionVariables, the variables are defined on the connection
+// instead, which is supported natively by SurrealDB v2.0.0 or later.
+//
 // References:
 // Bug: Using variables in filters does not emit live messages (https://github.com/surrealdb/surrealdb/issues/2623)
 // Bug: LQ params should be evaluated before registering (https://github.com/surrealdb/surrealdb/issues/2641)
@@ -301,38 +615,129 @@ func (c *Client) Query(ctx context.Context, query string, vars map[string]any) (
 // Feature: Live Query WHERE clause should process Params (https://github.com/surrealdb/surrealdb/issues/4026)
 // Docs: https://surrealdb.com/docs/surrealql/statements/live_select (bottom "other notes")
 //
-// TODO: prevent query from being more than one statement.
+// The query must consist of a single statement, otherwise ErrMultipleStatements is returned.
 func (c *Client) Live(ctx context.Context, query string, vars map[string]any) (<-chan []byte, error) {
 	// Note: rpc method "live" does not support advanced live queries where filters
 	// are needed, so we use the "query" method to initiate a custom live query.
 
-	varPrefix, err := randString(randomVariablePrefixLength)
+	if err := requireSingleStatement(query); err != nil {
+		return nil, fmt.Errorf("failed to validate live query: %w", err)
+	}
+
+	varPrefix, err := c.liveVarPrefix()
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate random string: %w", err)
+		return nil, err
 	}
 
-	params := make(map[string]string, len(vars))
+	names := make(map[string]string, len(vars))
 
 	for key := range vars {
-		newKey := varPrefix + "_" + key
-		params[newKey] = "DEFINE PARAM $" + newKey + " VALUE $" + key
-		query = strings.ReplaceAll(query, "$"+key, "$"+newKey)
+		names[key] = varPrefix + key
+	}
+
+	query, err = renameParams(query, names)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rename live query params: %w", err)
+	}
+
+	if c.liveLet && c.requireVersion("live query variables", version2) == nil {
+		return c.liveWithSessionVariables(ctx, query, vars, names)
+	}
+
+	return c.liveWithParams(ctx, query, vars, names)
+}
+
+// liveWithParams initiates the live query after defining
+// the renamed variables as params on the database.
+func (c *Client) liveWithParams(
+	ctx context.Context, query string, vars map[string]any, names map[string]string,
+) (
+	<-chan []byte, error,
+) {
+	var (
+		paramDefs  strings.Builder
+		paramNames = make([]string, 0, len(names))
+	)
+
+	for key, newKey := range names {
+		paramDefs.WriteString("DEFINE PARAM $" + newKey + " VALUE $" + key + "; ")
+		paramNames = append(paramNames, newKey)
 	}
 
-	query = livePrefix + " " + query
+	// Track the params before sending the request, so that they
+	// are removed on close, even if the response is never received.
+	c.liveParams.add(paramNames...)
 
-	if len(params) > 0 {
-		var paramDefs strings.Builder
+	liveKey, err := c.initLive(ctx, paramDefs.String()+livePrefix+" "+query, vars, len(paramNames))
+	if err != nil {
+		c.removeLiveParams(ctx, c.liveParams.take(paramNames...))
+
+		return nil, err
+	}
+
+	liveChan, ok := c.liveQueries.get(liveKey, true)
+	if !ok {
+		c.removeLiveParams(ctx, c.liveParams.take(paramNames...))
+
+		return nil, ErrCouldNotGetLiveQueryChannel
+	}
+
+	c.killLiveOnDone(ctx, liveKey, func(killCtx context.Context) {
+		c.removeLiveParams(killCtx, c.liveParams.take(paramNames...))
+	})
+
+	return liveChan, nil
+}
 
-		for _, value := range params {
-			if _, err := paramDefs.WriteString(value + "; "); err != nil {
-				return nil, fmt.Errorf("failed to write param definition: %w", err)
+// liveWithSessionVariables initiates the live query after defining
+// the renamed variables on the connection using Let.
+func (c *Client) liveWithSessionVariables(
+	ctx context.Context, query string, vars map[string]any, names map[string]string,
+) (
+	<-chan []byte, error,
+) {
+	varNames := make([]string, 0, len(names))
+
+	unset := func(ctx context.Context) {
+		for _, name := range varNames {
+			if err := c.Unset(ctx, name); err != nil {
+				c.logger.ErrorContext(ctx, "Could not unset variable.", "key", name, "error", err)
 			}
 		}
+	}
+
+	for key, newKey := range names {
+		if err := c.Let(ctx, newKey, vars[key]); err != nil {
+			unset(ctx)
+
+			return nil, fmt.Errorf("failed to define live query variable: %w", err)
+		}
+
+		varNames = append(varNames, newKey)
+	}
+
+	liveKey, err := c.initLive(ctx, livePrefix+" "+query, nil, 0)
+	if err != nil {
+		unset(ctx)
 
-		query = paramDefs.String() + query
+		return nil, err
 	}
 
+	liveChan, ok := c.liveQueries.get(liveKey, true)
+	if !ok {
+		unset(ctx)
+
+		return nil, ErrCouldNotGetLiveQueryChannel
+	}
+
+	c.killLiveOnDone(ctx, liveKey, unset)
+
+	return liveChan, nil
+}
+
+// initLive sends the query initiating a live query and returns its key,
+// which is the result of the statement at the given index.
+func (c *Client) initLive(ctx context.Context, query string, vars map[string]any, index int) (string, error) {
 	raw, err := c.send(ctx,
 		request{
 			Method: methodQuery,
@@ -343,31 +748,152 @@ func (c *Client) Live(ctx context.Context, query string, vars map[string]any) (<
 		},
 	)
 	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %w", err)
+		return "", fmt.Errorf("failed to send request: %w", err)
 	}
 
 	var res []basicResponse[[]byte]
 
 	if err := c.unmarshal(raw, &res); err != nil {
-		return nil, fmt.Errorf("could not unmarshal response: %w", err)
+		return "", fmt.Errorf("could not unmarshal response: %w", err)
 	}
 
-	// The last response contains the live key.
-	queryIndex := len(params)
+	if len(res) <= index || string(res[index].Result) == "" {
+		return "", ErrEmptyResponse
+	}
 
-	if len(res) < 1 || string(res[queryIndex].Result) == "" {
-		return nil, ErrEmptyResponse
+	return string(res[index].Result), nil
+}
+
+// liveVarPrefix returns a new prefix for the variables of a live query.
+// It follows the convention sdbc_live_[owner_]random_, so that leftover
+// params can be identified by sweepLiveParams.
+func (c *Client) liveVarPrefix() (string, error) {
+	random, err := randString(randomVariablePrefixLength)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate random string: %w", err)
+	}
+
+	if c.liveOwner == "" {
+		return liveParamPrefix + random + "_", nil
+	}
+
+	return liveParamPrefix + c.liveOwner + "_" + random + "_", nil
+}
+
+// removeLiveParams removes the given params from the database.
+// Errors are logged only, because there is nobody to return them to.
+func (c *Client) removeLiveParams(ctx context.Context, names []string) {
+	for _, name := range names {
+		if _, err := c.Query(ctx, "REMOVE PARAM $"+name+";", nil); err != nil {
+			c.logger.ErrorContext(ctx, "Could not remove param.", "key", name, "error", err)
+		}
+	}
+}
+
+// sweepLiveParams removes all params defined by Live for the
+// configured owner, which were not removed by a previous client.
+func (c *Client) sweepLiveParams(ctx context.Context) error {
+	raw, err := c.Query(ctx, "INFO FOR DB;", nil)
+	if err != nil {
+		return err
+	}
+
+	var res []basicResponse[databaseDefinitions]
+
+	if err := c.unmarshal(raw, &res); err != nil {
+		return fmt.Errorf("could not unmarshal response: %w", err)
+	}
+
+	if len(res) < 1 {
+		return ErrEmptyResponse
+	}
+
+	if res[0].Status != "OK" {
+		return ErrResponseNotOkay
+	}
+
+	names := leftoverLiveParams(res[0].Result, c.liveOwner)
+
+	c.logger.DebugContext(ctx, "Removing leftover live params.", "count", len(names))
+
+	for _, name := range names {
+		if _, err := c.Query(ctx, "REMOVE PARAM $"+name+";", nil); err != nil {
+			return fmt.Errorf("could not remove param %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// leftoverLiveParams returns the sorted names of all params
+// following the naming convention of Live for the given owner.
+func leftoverLiveParams(defs databaseDefinitions, owner string) []string {
+	prefix := liveParamPrefix + owner + "_"
+
+	var names []string
+
+	for name := range defs.Params {
+		name = strings.TrimPrefix(name, "$")
+
+		if strings.HasPrefix(name, prefix) && regexName.MatchString(name) {
+			names = append(names, name)
+		}
+	}
+
+	slices.Sort(names)
+
+	return names
+}
+
+// LiveTable initiates a live query for all records of the given table
+// and returns a channel to receive the results. If diff is true, the
+// notifications contain JSON patches instead of the full records.
+func (c *Client) LiveTable(ctx context.Context, table Table, diff bool) (<-chan []byte, error) {
+	if err := table.Validate(); err != nil {
+		return nil, err
+	}
+
+	raw, err := c.send(ctx,
+		request{
+			Method: methodLive,
+			Params: []any{
+				table,
+				diff,
+			},
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+
+	var liveKey []byte
+
+	if err := c.unmarshal(raw, &liveKey); err != nil {
+		return nil, fmt.Errorf("could not unmarshal response: %w", err)
 	}
 
-	liveKey := res[queryIndex].Result
+	if len(liveKey) < 1 {
+		return nil, ErrEmptyResponse
+	}
 
 	liveChan, ok := c.liveQueries.get(string(liveKey), true)
 	if !ok {
 		return nil, ErrCouldNotGetLiveQueryChannel
 	}
 
+	c.killLiveOnDone(ctx, string(liveKey), nil)
+
+	return liveChan, nil
+}
+
+// killLiveOnDone kills the live query with the given key as soon as the
+// context is done. The optional cleanup function is called afterward.
+func (c *Client) killLiveOnDone(ctx context.Context, key string, cleanup func(killCtx context.Context)) {
+	// Keep the context, so that notifications can be related to the live query.
+	c.liveQueries.setContext(key, ctx)
+
 	c.waitGroup.Add(1)
-	go func(key string) {
+	go func() {
 		defer c.waitGroup.Done()
 
 		select {
@@ -401,14 +927,10 @@ func (c *Client) Live(ctx context.Context, query string, vars map[string]any) (<
 			c.logger.ErrorContext(killCtx, "Could not kill live query.", "key", key, "error", err)
 		}
 
-		for newKey := range params {
-			if _, err := c.Query(killCtx, fmt.Sprintf("REMOVE PARAM $%s;", newKey), nil); err != nil {
-				c.logger.ErrorContext(killCtx, "Could not remove param.", "key", newKey, "error", err)
-			}
+		if cleanup != nil {
+			cleanup(killCtx)
 		}
-	}(string(liveKey))
-
-	return liveChan, nil
+	}()
 }
 
 // Kill an active live query.
@@ -434,7 +956,12 @@ func (c *Client) Kill(ctx context.Context, uuid string) ([]byte, error) {
 
 // Relate creates a graph relationship between two records.
 // Data is optional and only submitted if it is not nil.
+// It requires SurrealDB v2.0.0 or later, otherwise ErrUnsupportedByServer is returned.
 func (c *Client) Relate(ctx context.Context, in *ID, relation RecordID, out *ID, data any) ([]byte, error) {
+	if err := c.requireVersion("relate", version2); err != nil {
+		return nil, err
+	}
+
 	params := []any{
 		in,
 		relation,
@@ -461,7 +988,18 @@ func (c *Client) Relate(ctx context.Context, in *ID, relation RecordID, out *ID,
 // InsertRelation inserts a new relation record into the database.
 // Data needs to specify both the in and out records.
 // If table is nil, the relation table is inferred from the data record ID field.
-func (c *Client) InsertRelation(ctx context.Context, table *string, data any) ([]byte, error) {
+// It requires SurrealDB v2.0.0 or later, otherwise ErrUnsupportedByServer is returned.
+func (c *Client) InsertRelation(ctx context.Context, table *Table, data any) ([]byte, error) {
+	if err := c.requireVersion("insert_relation", version2); err != nil {
+		return nil, err
+	}
+
+	if table != nil {
+		if err := table.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
 	res, err := c.send(ctx,
 		request{
 			Method: methodInsertRelation,
@@ -518,7 +1056,12 @@ func (c *Client) Unset(ctx context.Context, name string) error {
 }
 
 // Run executes built-in functions, custom functions, or machine learning models with optional arguments.
+// It requires SurrealDB v2.0.0 or later, otherwise ErrUnsupportedByServer is returned.
 func (c *Client) Run(ctx context.Context, name string, version *string, args []any) ([]byte, error) {
+	if err := c.requireVersion("run", version2); err != nil {
+		return nil, err
+	}
+
 	res, err := c.send(ctx,
 		request{
 			Method: methodRun,
@@ -537,8 +1080,12 @@ func (c *Client) Run(ctx context.Context, name string, version *string, args []a
 }
 
 // GraphQL executes graphql queries against the database.
-// Note: Requires SurrealDB v2.0.0 or later.
+// It requires SurrealDB v2.0.0 or later, otherwise ErrUnsupportedByServer is returned.
 func (c *Client) GraphQL(ctx context.Context, req GraphqlRequest) ([]byte, error) {
+	if err := c.requireVersion("graphql", version2); err != nil {
+		return nil, err
+	}
+
 	res, err := c.send(ctx,
 		request{
 			Method: methodGraphQL,
@@ -597,18 +1144,58 @@ type GraphqlRequest struct {
 //
 
 func (c *Client) send(ctx context.Context, req request) ([]byte, error) {
-	var err error
-	defer c.checkWebsocketConn(err)
+	if len(c.interceptors) > 0 {
+		return c.intercept(ctx, req)
+	}
+
+	return c.invoke(ctx, req)
+}
+
+// invoke sends the request and records it with the tracer and metrics.
+func (c *Client) invoke(ctx context.Context, req request) ([]byte, error) {
+	var (
+		res   []byte
+		err   error
+		start = time.Now()
+	)
+
+	if c.tracer != nil {
+		res, err = c.traceRequest(ctx, req)
+	} else {
+		res, err = c.roundTrip(ctx, req)
+	}
+
+	roundTrip := time.Since(start)
 
+	c.metrics.ObserveRequest(req.Method, roundTrip, err)
+
+	if req.Method == methodQuery && (c.slowQueries != nil || c.queryStats != nil) {
+		c.observeQuery(ctx, req, res, err, roundTrip)
+	}
+
+	return res, err
+}
+
+// roundTrip writes the request and waits for its response.
+func (c *Client) roundTrip(ctx context.Context, req request) ([]byte, error) {
 	reqID, resCh := c.requests.prepare()
-	defer c.requests.del(reqID)
+	c.metrics.SetRequestsInFlight(c.requests.len())
+
+	defer func() {
+		c.requests.del(reqID)
+		c.metrics.SetRequestsInFlight(c.requests.len())
+	}()
 
 	req.ID = reqID
 
+	if sensitiveMethods[req.Method] {
+		c.requests.redact(reqID)
+	}
+
 	c.logger.DebugContext(ctx, "Sending request.",
 		"id", req.ID,
 		"method", req.Method,
-		"params", req.Params,
+		"params", logParams{client: c, req: req},
 	)
 
 	if err := c.write(ctx, req); err != nil {
@@ -629,11 +1216,71 @@ func (c *Client) send(ctx context.Context, req request) ([]byte, error) {
 	}
 }
 
+// queryRecords executes a query consisting of a single statement
+// and returns the resulting records one by one.
+func (c *Client) queryRecords(ctx context.Context, query string, vars map[string]any) ([][]byte, error) {
+	results, err := c.queryResults(ctx, query, vars)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []cbor.RawMessage
+
+	if err := c.unmarshal(results[0], &records); err != nil {
+		return nil, fmt.Errorf("could not unmarshal records: %w", err)
+	}
+
+	out := make([][]byte, len(records))
+	for index, record := range records {
+		out[index] = record
+	}
+
+	return out, nil
+}
+
+// queryResults executes the query and returns the result of each statement.
+// If a statement failed, an error with the message of the first failed
+// statement is returned. The result contains at least one element.
+func (c *Client) queryResults(ctx context.Context, query string, vars map[string]any) ([]cbor.RawMessage, error) {
+	raw, err := c.Query(ctx, query, vars)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []basicResponse[cbor.RawMessage]
+
+	if err := c.unmarshal(raw, &res); err != nil {
+		return nil, fmt.Errorf("could not unmarshal response: %w", err)
+	}
+
+	if len(res) < 1 {
+		return nil, ErrEmptyResponse
+	}
+
+	results := make([]cbor.RawMessage, len(res))
+
+	for index, item := range res {
+		if item.Status != "OK" {
+			var msg string
+
+			_ = c.unmarshal(item.Result, &msg) // the message is for information only
+
+			return nil, fmt.Errorf("%w: %s", ErrResponseNotOkay, msg)
+		}
+
+		results[index] = item.Result
+	}
+
+	return results, nil
+}
+
 // write writes the JSON message v to c.
 // It will reuse buffers in between calls to avoid allocations.
 func (c *Client) write(ctx context.Context, req request) error {
 	var err error
-	defer c.checkWebsocketConn(err)
+
+	// The closure reads err when returning, not when deferring.
+	defer func() { c.checkWebsocketConn(err) }()
 
 	data, err := c.marshal(req)
 	if err != nil {
@@ -645,6 +1292,8 @@ func (c *Client) write(ctx context.Context, req request) error {
 		return fmt.Errorf("failed to write message: %w", err)
 	}
 
+	c.metrics.AddBytesWritten(len(data))
+
 	// TODO: use Writer instead of Write to stream the message?
 	return nil
 }
@@ -0,0 +1,50 @@
+package sdbc
+
+import (
+	"time"
+)
+
+// Metrics records metrics about the requests, the websocket connection and
+// the live query notifications of the client, e.g. as counters, gauges and
+// histograms. It is set with WithMetrics. See package sdbcprom for a Prometheus
+// implementation. The methods are called concurrently and must not block.
+type Metrics interface {
+	// ObserveRequest is called once the response of a request has been
+	// received (or the request failed) with the RPC method, the time since
+	// the request was sent and the error of the request, if any.
+	ObserveRequest(method string, duration time.Duration, err error)
+
+	// SetRequestsInFlight is called with the number of requests waiting
+	// for a response whenever a request is sent or completed.
+	SetRequestsInFlight(count int)
+
+	// AddBytesWritten is called with the size of each message written to the websocket.
+	AddBytesWritten(count int)
+
+	// AddBytesRead is called with the size of each message read from the websocket.
+	AddBytesRead(count int)
+
+	// IncReconnects is called when the websocket connection was closed
+	// unexpectedly and a reconnect has been attempted. The error is the
+	// one of the reconnect, or nil if it succeeded.
+	IncReconnects(err error)
+
+	// IncDroppedNotifications is called when a live query notification
+	// could not be delivered to its channel (see ErrLiveNotificationDropped).
+	IncDroppedNotifications()
+}
+
+// emptyMetrics is the default Metrics, which records nothing.
+type emptyMetrics struct{}
+
+func (m emptyMetrics) ObserveRequest(_ string, _ time.Duration, _ error) {}
+
+func (m emptyMetrics) SetRequestsInFlight(_ int) {}
+
+func (m emptyMetrics) AddBytesWritten(_ int) {}
+
+func (m emptyMetrics) AddBytesRead(_ int) {}
+
+func (m emptyMetrics) IncReconnects(_ error) {}
+
+func (m emptyMetrics) IncDroppedNotifications() {}
@@ -0,0 +1,172 @@
+package sdbc
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/poll"
+)
+
+func TestMetricsRequests(t *testing.T) {
+	t.Parallel()
+
+	metrics := &fakeMetrics{}
+
+	client := newWebsocketTestClient(t, func(req request) (any, *responseError) {
+		if req.Method == methodQuery {
+			return nil, &responseError{Code: -32000, Message: "Parse error"}
+		}
+
+		return "surrealdb-2.1.0", nil
+	}, WithMetrics(metrics))
+
+	version, err := client.Version(context.Background())
+	assert.NilError(t, err)
+	assert.Equal(t, "2.1.0", version)
+
+	_, err = client.Query(context.Background(), "SELECT", nil)
+	assert.Check(t, errors.Is(err, ErrResultWithError))
+
+	metrics.mut.Lock()
+	defer metrics.mut.Unlock()
+
+	assert.DeepEqual(t, []string{methodVersion, methodQuery}, metrics.methods)
+	assert.Equal(t, 1, metrics.errors)
+	assert.DeepEqual(t, []int{1, 0, 1, 0}, metrics.inFlight)
+	assert.Check(t, metrics.bytesWritten > 0)
+	assert.Check(t, metrics.bytesRead > 0)
+}
+
+func TestMetricsDroppedNotifications(t *testing.T) {
+	t.Parallel()
+
+	marshal, unmarshal := newTestCodec(t, nil)
+	metrics := &fakeMetrics{}
+
+	client := &Client{
+		options:     applyOptions([]Option{WithMetrics(metrics), WithTimeout(10 * time.Millisecond)}),
+		marshal:     marshal,
+		unmarshal:   unmarshal,
+		liveQueries: newLiveQueries(),
+		connCtx:     context.Background(),
+	}
+
+	client.liveQueries.get("known_id", true)
+
+	result, err := marshal(liveResponse[string]{ID: []byte("known_id"), Action: "CREATE", Result: "record"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Nobody reads the channel, so the notification is dropped.
+	client.handleLiveQuery(&response{Result: result})
+
+	metrics.mut.Lock()
+	defer metrics.mut.Unlock()
+
+	assert.Equal(t, 1, metrics.droppedNotifications)
+}
+
+func TestMetricsReconnects(t *testing.T) {
+	t.Parallel()
+
+	var broken atomic.Bool
+
+	metrics := &fakeMetrics{}
+
+	client := newWebsocketTestClient(t, func(_ request) (any, *responseError) {
+		if broken.CompareAndSwap(false, true) {
+			return errBreakConnection, nil
+		}
+
+		return "surrealdb-2.1.0", nil
+	}, WithMetrics(metrics))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	// The connection breaks before the response is sent.
+	_, err := client.Version(ctx)
+	assert.Check(t, errors.Is(err, context.DeadlineExceeded))
+
+	poll.WaitOn(t, func(poll.LogT) poll.Result {
+		metrics.mut.Lock()
+		defer metrics.mut.Unlock()
+
+		if metrics.reconnects == 0 {
+			return poll.Continue("no reconnect recorded yet")
+		}
+
+		return poll.Success()
+	}, poll.WithTimeout(time.Second))
+
+	version, err := client.Version(context.Background())
+	assert.NilError(t, err)
+	assert.Equal(t, "2.1.0", version)
+}
+
+//
+// -- HELPER
+//
+
+type fakeMetrics struct {
+	mut                  sync.Mutex
+	methods              []string
+	errors               int
+	inFlight             []int
+	bytesWritten         int
+	bytesRead            int
+	reconnects           int
+	droppedNotifications int
+}
+
+func (m *fakeMetrics) ObserveRequest(method string, _ time.Duration, err error) {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	m.methods = append(m.methods, method)
+
+	if err != nil {
+		m.errors++
+	}
+}
+
+func (m *fakeMetrics) SetRequestsInFlight(count int) {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	m.inFlight = append(m.inFlight, count)
+}
+
+func (m *fakeMetrics) AddBytesWritten(count int) {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	m.bytesWritten += count
+}
+
+func (m *fakeMetrics) AddBytesRead(count int) {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	m.bytesRead += count
+}
+
+func (m *fakeMetrics) IncReconnects(_ error) {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	m.reconnects++
+}
+
+func (m *fakeMetrics) IncDroppedNotifications() {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	m.droppedNotifications++
+}
@@ -0,0 +1,380 @@
+// Package migrate applies versioned SurrealQL migrations to a database.
+//
+// Migrations are read from a file system (e.g. an embed.FS), see Load for
+// the naming convention. Each migration is applied within a transaction,
+// together with a record of its version and checksum in a tracking table.
+// A lock record guards against multiple runners applying migrations at
+// the same time. The package works with any client providing Query and
+// Unmarshal, like *sdbc.Client.
+package migrate
+
+import (
+	"cmp"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+const (
+	defaultTable   = "_migrations"
+	defaultLockTTL = 10 * time.Minute
+
+	lockTableSuffix = "_lock"
+	lockRecordID    = "lock"
+	ownerLength     = 16
+
+	statusOK = "OK"
+
+	// failedTransaction is part of the error reported for all statements
+	// of a failed transaction, except for the one that actually failed.
+	failedTransaction = "failed transaction"
+)
+
+var (
+	ErrLocked           = errors.New("migrations are locked by another runner")
+	ErrChecksumMismatch = errors.New("checksum of applied migration does not match")
+	ErrUnknownVersion   = errors.New("applied migration is unknown")
+	ErrOutOfOrder       = errors.New("pending migration is older than the latest applied migration")
+	ErrQueryFailed      = errors.New("query failed")
+)
+
+// Client is the subset of *sdbc.Client required to apply migrations.
+type Client interface {
+	Query(ctx context.Context, query string, vars map[string]any) ([]byte, error)
+	Unmarshal(data []byte, val any) error
+}
+
+// Migrator applies migrations to a database.
+type Migrator struct {
+	client     Client
+	migrations []Migration
+	table      string
+	lockTTL    time.Duration
+	dryRun     bool
+}
+
+type Option func(*Migrator)
+
+// WithTable sets the name of the table tracking the applied migrations.
+// The lock record is stored in a table with the suffix _lock.
+// If not set, the table _migrations is used.
+func WithTable(table string) Option {
+	return func(m *Migrator) {
+		m.table = table
+	}
+}
+
+// WithLockTTL sets the time after which the lock of a runner is considered
+// stale, e.g. because the runner crashed. It must be longer than applying all
+// migrations takes. If not set, the default time to live is 10 minutes.
+func WithLockTTL(ttl time.Duration) Option {
+	return func(m *Migrator) {
+		m.lockTTL = ttl
+	}
+}
+
+// WithDryRun makes Up return the pending migrations without applying them.
+func WithDryRun() Option {
+	return func(m *Migrator) {
+		m.dryRun = true
+	}
+}
+
+// New creates a migrator for the migrations in the given directory of the file system.
+func New(client Client, fsys fs.FS, dir string, opts ...Option) (*Migrator, error) {
+	migrations, err := Load(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(migrations) == 0 {
+		return nil, ErrNoMigrationsFound
+	}
+
+	migrator := &Migrator{
+		client:     client,
+		migrations: migrations,
+		table:      defaultTable,
+		lockTTL:    defaultLockTTL,
+	}
+
+	for _, opt := range opts {
+		opt(migrator)
+	}
+
+	return migrator, nil
+}
+
+// AppliedMigration is a migration recorded in the tracking table.
+type AppliedMigration struct {
+	Version   uint64
+	Name      string
+	Checksum  string
+	AppliedAt time.Time
+}
+
+// Up applies all pending migrations in ascending order and returns them.
+// If a migration fails, the migrations applied before are returned along
+// with the error. Before applying anything, the applied migrations are
+// verified against the known ones, so that modified or missing migration
+// files are detected. In dry-run mode, the pending migrations are returned
+// without applying them.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	if m.dryRun {
+		return m.Pending(ctx)
+	}
+
+	owner, err := newOwner()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := m.lock(ctx, owner); err != nil {
+		return nil, err
+	}
+
+	defer m.unlock(context.WithoutCancel(ctx), owner)
+
+	pending, err := m.Pending(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	applied := make([]Migration, 0, len(pending))
+
+	for _, migration := range pending {
+		if err := m.apply(ctx, migration); err != nil {
+			return applied, fmt.Errorf("could not apply migration %d (%s): %w", migration.Version, migration.Name, err)
+		}
+
+		applied = append(applied, migration)
+	}
+
+	return applied, nil
+}
+
+// Pending returns the migrations not applied yet, after verifying
+// the applied migrations against the known ones.
+func (m *Migrator) Pending(ctx context.Context) ([]Migration, error) {
+	applied, err := m.Applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.pending(applied)
+}
+
+func (m *Migrator) pending(applied []AppliedMigration) ([]Migration, error) {
+	known := make(map[uint64]Migration, len(m.migrations))
+	for _, migration := range m.migrations {
+		known[migration.Version] = migration
+	}
+
+	var latest uint64
+
+	done := make(map[uint64]bool, len(applied))
+
+	for _, record := range applied {
+		migration, ok := known[record.Version]
+		if !ok {
+			return nil, fmt.Errorf("%w: %d (%s)", ErrUnknownVersion, record.Version, record.Name)
+		}
+
+		if migration.Checksum != record.Checksum {
+			return nil, fmt.Errorf("%w: %d (%s)", ErrChecksumMismatch, record.Version, record.Name)
+		}
+
+		done[record.Version] = true
+		latest = max(latest, record.Version)
+	}
+
+	var pending []Migration
+
+	for _, migration := range m.migrations {
+		if done[migration.Version] {
+			continue
+		}
+
+		if migration.Version < latest {
+			return nil, fmt.Errorf("%w: %d (%s)", ErrOutOfOrder, migration.Version, migration.Name)
+		}
+
+		pending = append(pending, migration)
+	}
+
+	return pending, nil
+}
+
+// Applied returns the migrations recorded in the tracking table in ascending order.
+func (m *Migrator) Applied(ctx context.Context) ([]AppliedMigration, error) {
+	results, err := m.query(ctx,
+		"SELECT version, name, checksum, <string> applied_at AS applied_at FROM type::table($table);",
+		map[string]any{
+			"table": m.table,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []struct {
+		Version   uint64 `cbor:"version"`
+		Name      string `cbor:"name"`
+		Checksum  string `cbor:"checksum"`
+		AppliedAt string `cbor:"applied_at"`
+	}
+
+	if err := m.client.Unmarshal(results[0], &records); err != nil {
+		return nil, fmt.Errorf("could not unmarshal applied migrations: %w", err)
+	}
+
+	applied := make([]AppliedMigration, len(records))
+
+	for index, record := range records {
+		appliedAt, err := time.Parse(time.RFC3339Nano, record.AppliedAt)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse time of applied migration %d: %w", record.Version, err)
+		}
+
+		applied[index] = AppliedMigration{
+			Version:   record.Version,
+			Name:      record.Name,
+			Checksum:  record.Checksum,
+			AppliedAt: appliedAt,
+		}
+	}
+
+	slices.SortFunc(applied, func(a, b AppliedMigration) int {
+		return cmp.Compare(a.Version, b.Version)
+	})
+
+	return applied, nil
+}
+
+// apply runs the migration and records it within a single transaction.
+func (m *Migrator) apply(ctx context.Context, migration Migration) error {
+	// The migration is followed by a line break, so that
+	// a trailing line comment does not swallow the semicolon.
+	query := "BEGIN TRANSACTION;\n" +
+		migration.Query + "\n;\n" +
+		"CREATE type::thing($table, $version) CONTENT {" +
+		" version: $version, name: $name, checksum: $checksum, applied_at: time::now() };\n" +
+		"COMMIT TRANSACTION;"
+
+	_, err := m.query(ctx, query, map[string]any{
+		"table":    m.table,
+		"version":  migration.Version,
+		"name":     migration.Name,
+		"checksum": migration.Checksum,
+	})
+
+	return err
+}
+
+// lock creates the lock record, after removing a stale one.
+func (m *Migrator) lock(ctx context.Context, owner string) error {
+	_, err := m.query(ctx,
+		"DELETE type::thing($table, $id) WHERE expires_at < time::now();\n"+
+			"CREATE type::thing($table, $id) CONTENT { owner: $owner, expires_at: time::now() + <duration> $ttl };",
+		map[string]any{
+			"table": m.table + lockTableSuffix,
+			"id":    lockRecordID,
+			"owner": owner,
+			"ttl":   strconv.FormatInt(m.lockTTL.Milliseconds(), 10) + "ms",
+		},
+	)
+	if errors.Is(err, ErrQueryFailed) {
+		return fmt.Errorf("%w: %w", ErrLocked, err)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// unlock removes the lock record, if it is still owned by the given owner.
+func (m *Migrator) unlock(ctx context.Context, owner string) {
+	_, _ = m.query(ctx,
+		"DELETE type::thing($table, $id) WHERE owner = $owner;",
+		map[string]any{
+			"table": m.table + lockTableSuffix,
+			"id":    lockRecordID,
+			"owner": owner,
+		},
+	)
+}
+
+type response struct {
+	Status string          `cbor:"status"`
+	Result cbor.RawMessage `cbor:"result"`
+}
+
+// query executes the query and returns the results of its statements.
+// If any statement fails, the error of the failed statement is returned.
+func (m *Migrator) query(ctx context.Context, query string, vars map[string]any) ([]cbor.RawMessage, error) {
+	raw, err := m.client.Query(ctx, query, vars)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []response
+
+	if err := m.client.Unmarshal(raw, &res); err != nil {
+		return nil, fmt.Errorf("could not unmarshal response: %w", err)
+	}
+
+	if len(res) == 0 {
+		return nil, fmt.Errorf("%w: empty response", ErrQueryFailed)
+	}
+
+	var messages []string
+
+	results := make([]cbor.RawMessage, len(res))
+
+	for index, item := range res {
+		if item.Status == statusOK {
+			results[index] = item.Result
+
+			continue
+		}
+
+		var message string
+		_ = m.client.Unmarshal(item.Result, &message)
+
+		messages = append(messages, message)
+	}
+
+	if len(messages) == 0 {
+		return results, nil
+	}
+
+	// Prefer the error of the statement that actually failed.
+	for _, message := range messages {
+		if !strings.Contains(message, failedTransaction) {
+			return nil, fmt.Errorf("%w: %s", ErrQueryFailed, message)
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrQueryFailed, messages[0])
+}
+
+func newOwner() (string, error) {
+	owner := make([]byte, ownerLength)
+
+	if _, err := rand.Read(owner); err != nil {
+		return "", fmt.Errorf("could not generate lock owner: %w", err)
+	}
+
+	return hex.EncodeToString(owner), nil
+}
@@ -0,0 +1,282 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+	"gotest.tools/v3/assert"
+)
+
+var testFiles = fstest.MapFS{
+	"0001_create_user.surql": {Data: []byte("DEFINE TABLE user SCHEMAFULL;")},
+	"0002_add_email.surql":   {Data: []byte("DEFINE FIELD email ON user TYPE string; -- comment")},
+	"0003_seed.surql":        {Data: []byte("CREATE user:admin SET email = 'admin@example.com';")},
+}
+
+func TestUp(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client := newFakeClient()
+
+	migrator, err := New(client, testFiles, ".")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	applied, err := migrator.Up(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.DeepEqual(t, []uint64{1, 2, 3}, versions(applied))
+	assert.Check(t, !client.locked, "lock not released")
+
+	// Each migration is applied within a transaction, together with its record.
+
+	query := client.queries[2]
+	assert.Check(t, strings.HasPrefix(query, "BEGIN TRANSACTION;\nDEFINE TABLE user SCHEMAFULL;\n;\nCREATE"), query)
+	assert.Check(t, strings.HasSuffix(query, "COMMIT TRANSACTION;"), query)
+
+	// A second run has nothing to do.
+
+	applied, err = migrator.Up(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 0, len(applied))
+
+	records, err := migrator.Applied(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 3, len(records))
+	assert.Equal(t, "add_email", records[1].Name)
+	assert.Equal(t, checksum("DEFINE FIELD email ON user TYPE string; -- comment"), records[1].Checksum)
+	assert.Check(t, !records[1].AppliedAt.IsZero())
+}
+
+func TestUpDryRun(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client := newFakeClient()
+	client.addRecord(1, "create_user", checksum("DEFINE TABLE user SCHEMAFULL;"))
+
+	migrator, err := New(client, testFiles, ".", WithDryRun())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pending, err := migrator.Up(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.DeepEqual(t, []uint64{2, 3}, versions(pending))
+	assert.Equal(t, 1, len(client.records), "nothing must be applied")
+	assert.Equal(t, 1, len(client.queries), "no lock must be acquired")
+}
+
+func TestUpLocked(t *testing.T) {
+	t.Parallel()
+
+	client := newFakeClient()
+	client.locked = true
+
+	migrator, err := New(client, testFiles, ".")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = migrator.Up(context.Background())
+	assert.Check(t, errors.Is(err, ErrLocked))
+	assert.Equal(t, 0, len(client.records))
+	assert.Check(t, client.locked, "lock of other runner must be kept")
+}
+
+func TestUpFailure(t *testing.T) {
+	t.Parallel()
+
+	files := fstest.MapFS{
+		"0001_ok.surql":   {Data: []byte("DEFINE TABLE user;")},
+		"0002_fail.surql": {Data: []byte("THROW 'FAIL';")},
+		"0003_ok.surql":   {Data: []byte("DEFINE TABLE post;")},
+	}
+
+	client := newFakeClient()
+
+	migrator, err := New(client, files, ".")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	applied, err := migrator.Up(context.Background())
+	assert.Check(t, errors.Is(err, ErrQueryFailed))
+	assert.ErrorContains(t, err, "An error occurred: FAIL")
+	assert.DeepEqual(t, []uint64{1}, versions(applied))
+	assert.Equal(t, 1, len(client.records))
+	assert.Check(t, !client.locked, "lock not released")
+}
+
+func TestPendingVerification(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		record func(client *fakeClient)
+		err    error
+	}{
+		{
+			record: func(client *fakeClient) { client.addRecord(1, "create_user", "modified") },
+			err:    ErrChecksumMismatch,
+		},
+		{
+			record: func(client *fakeClient) { client.addRecord(4, "unknown", "x") },
+			err:    ErrUnknownVersion,
+		},
+		{
+			record: func(client *fakeClient) {
+				client.addRecord(1, "create_user", checksum("DEFINE TABLE user SCHEMAFULL;"))
+				client.addRecord(3, "seed", checksum("CREATE user:admin SET email = 'admin@example.com';"))
+			},
+			err: ErrOutOfOrder,
+		},
+	}
+
+	for _, test := range tests {
+		client := newFakeClient()
+		test.record(client)
+
+		migrator, err := New(client, testFiles, ".")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = migrator.Pending(context.Background())
+		assert.Check(t, errors.Is(err, test.err), "expected %v, got %v", test.err, err)
+	}
+}
+
+func TestNewWithoutMigrations(t *testing.T) {
+	t.Parallel()
+
+	_, err := New(newFakeClient(), fstest.MapFS{"README.md": {}}, ".")
+	assert.Check(t, errors.Is(err, ErrNoMigrationsFound))
+}
+
+//
+// -- HELPER
+//
+
+func versions(migrations []Migration) []uint64 {
+	out := make([]uint64, len(migrations))
+	for index, migration := range migrations {
+		out[index] = migration.Version
+	}
+
+	return out
+}
+
+type fakeRecord struct {
+	Version   uint64 `cbor:"version"`
+	Name      string `cbor:"name"`
+	Checksum  string `cbor:"checksum"`
+	AppliedAt string `cbor:"applied_at"`
+}
+
+// fakeClient simulates the tracking and lock tables used by the migrator.
+type fakeClient struct {
+	mut     sync.Mutex
+	queries []string
+	records []fakeRecord
+	locked  bool
+}
+
+func newFakeClient() *fakeClient {
+	return &fakeClient{}
+}
+
+func (c *fakeClient) addRecord(version uint64, name, sum string) {
+	c.records = append(c.records, fakeRecord{
+		Version:   version,
+		Name:      name,
+		Checksum:  sum,
+		AppliedAt: time.Now().UTC().Format(time.RFC3339Nano),
+	})
+}
+
+func (c *fakeClient) Query(_ context.Context, query string, vars map[string]any) ([]byte, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	c.queries = append(c.queries, query)
+
+	switch {
+
+	case strings.HasPrefix(query, "SELECT"):
+		return cbor.Marshal([]response{ok(c.records)})
+
+	case strings.Contains(query, "WHERE expires_at"):
+		if c.locked {
+			return cbor.Marshal([]response{ok(nil), failed("Database record `lock` already exists")})
+		}
+
+		c.locked = true
+
+		return cbor.Marshal([]response{ok(nil), ok(nil)})
+
+	case strings.Contains(query, "WHERE owner"):
+		c.locked = false
+
+		return cbor.Marshal([]response{ok(nil)})
+
+	case strings.HasPrefix(query, "BEGIN TRANSACTION"):
+		if strings.Contains(query, "FAIL") {
+			return cbor.Marshal([]response{
+				failed("An error occurred: FAIL"),
+				failed("The query was not executed due to a failed transaction"),
+			})
+		}
+
+		version, _ := vars["version"].(uint64)
+		name, _ := vars["name"].(string)
+		sum, _ := vars["checksum"].(string)
+
+		c.addRecord(version, name, sum)
+
+		return cbor.Marshal([]response{ok(nil), ok(nil)})
+
+	default:
+		return nil, errors.New("unexpected query: " + query) //nolint:err113 // test only
+	}
+}
+
+func (c *fakeClient) Unmarshal(data []byte, val any) error {
+	return cbor.Unmarshal(data, val)
+}
+
+func ok(result any) response {
+	data, err := cbor.Marshal(result)
+	if err != nil {
+		panic(err)
+	}
+
+	return response{Status: statusOK, Result: data}
+}
+
+func failed(message string) response {
+	data, err := cbor.Marshal(message)
+	if err != nil {
+		panic(err)
+	}
+
+	return response{Status: "ERR", Result: data}
+}
@@ -0,0 +1,188 @@
+package migrate
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/go-surreal/sdbc/internal/surrealql"
+)
+
+const fileExtension = ".surql"
+
+var (
+	ErrInvalidFileName   = errors.New("invalid migration file name")
+	ErrDuplicateVersion  = errors.New("duplicate migration version")
+	ErrEmptyMigration    = errors.New("migration is empty")
+	ErrNoMigrationsFound = errors.New("no migrations found")
+	ErrInvalidMigration  = errors.New("invalid migration")
+)
+
+// transactionKeywords are the statements that must not be part of a
+// migration, because each migration is applied within a transaction.
+var transactionKeywords = []string{"BEGIN", "COMMIT", "CANCEL"}
+
+// Migration is a single versioned migration.
+type Migration struct {
+	// Version is the version of the migration. Migrations are applied in ascending order.
+	Version uint64
+
+	// Name is the descriptive part of the file name.
+	Name string
+
+	// Query is the SurrealQL content of the migration.
+	Query string
+
+	// Checksum is the hex encoded SHA-256 hash of the query.
+	Checksum string
+}
+
+// Load reads all migrations from the given directory of the file system
+// (e.g. an embed.FS). The files must be named <version>_<name>.surql,
+// e.g. 0001_create_users.surql, where version is a positive integer.
+// Other files and subdirectories are ignored. The migrations are
+// returned in ascending order of their versions. As each migration is
+// applied within a transaction, it must not contain transaction
+// statements (BEGIN, COMMIT or CANCEL) itself.
+func Load(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("could not read migrations directory: %w", err)
+	}
+
+	var migrations []Migration
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), fileExtension) {
+			continue
+		}
+
+		version, name, err := parseFileName(entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		content, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("could not read migration %s: %w", entry.Name(), err)
+		}
+
+		query := string(content)
+
+		if strings.TrimSpace(query) == "" {
+			return nil, fmt.Errorf("%w: %s", ErrEmptyMigration, entry.Name())
+		}
+
+		if err := checkStatements(query); err != nil {
+			return nil, fmt.Errorf("%w %s: %w", ErrInvalidMigration, entry.Name(), err)
+		}
+
+		migrations = append(migrations, Migration{
+			Version:  version,
+			Name:     name,
+			Query:    query,
+			Checksum: checksum(query),
+		})
+	}
+
+	slices.SortFunc(migrations, func(a, b Migration) int {
+		return cmp.Compare(a.Version, b.Version)
+	})
+
+	for index := 1; index < len(migrations); index++ {
+		if migrations[index].Version == migrations[index-1].Version {
+			return nil, fmt.Errorf("%w: %d", ErrDuplicateVersion, migrations[index].Version)
+		}
+	}
+
+	return migrations, nil
+}
+
+// parseFileName splits the file name into version and name.
+func parseFileName(fileName string) (uint64, string, error) {
+	base := strings.TrimSuffix(fileName, fileExtension)
+
+	rawVersion, name, ok := strings.Cut(base, "_")
+	if !ok || name == "" {
+		return 0, "", fmt.Errorf("%w: %s", ErrInvalidFileName, fileName)
+	}
+
+	version, err := strconv.ParseUint(rawVersion, 10, 64)
+	if err != nil || version == 0 {
+		return 0, "", fmt.Errorf("%w: %s", ErrInvalidFileName, fileName)
+	}
+
+	return version, name, nil
+}
+
+// checkStatements returns an error if one of the statements of
+// the query is a transaction statement (see transactionKeywords).
+func checkStatements(query string) error {
+	tokens, err := surrealql.Lex(query)
+	if err != nil {
+		return fmt.Errorf("could not parse query: %w", err)
+	}
+
+	var (
+		depth int
+		start = true // whether the next token starts a statement
+	)
+
+	for _, tok := range tokens {
+		switch tok.Kind {
+
+		case surrealql.TokenSpace, surrealql.TokenComment:
+			continue
+
+		case surrealql.TokenSemicolon:
+			start = start || depth == 0
+
+			continue
+
+		case surrealql.TokenOpen:
+			depth++
+
+		case surrealql.TokenClose:
+			depth = max(depth-1, 0)
+
+		case surrealql.TokenOther:
+			keyword := firstWord(query[tok.Start:tok.End])
+
+			if start && slices.Contains(transactionKeywords, keyword) {
+				return fmt.Errorf("unexpected %s statement at position %d", keyword, tok.Start)
+			}
+
+		default:
+		}
+
+		start = false
+	}
+
+	return nil
+}
+
+// firstWord returns the leading identifier characters of the text in upper case.
+func firstWord(text string) string {
+	end := strings.IndexFunc(text, func(char rune) bool {
+		return !surrealql.IsIdentChar(char)
+	})
+
+	if end < 0 {
+		end = len(text)
+	}
+
+	return strings.ToUpper(text[:end])
+}
+
+func checksum(query string) string {
+	sum := sha256.Sum256([]byte(query))
+
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,101 @@
+package migrate
+
+import (
+	"errors"
+	"testing"
+	"testing/fstest"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		"migrations/0002_add_index.surql":   {Data: []byte("DEFINE INDEX email ON user FIELDS email UNIQUE;")},
+		"migrations/0001_create_user.surql": {Data: []byte("DEFINE TABLE user SCHEMAFULL;")},
+		"migrations/10_seed.surql":          {Data: []byte("-- COMMIT\nCREATE user:admin SET commit = 'BEGIN';")},
+		"migrations/README.md":              {Data: []byte("ignored")},
+		"migrations/nested/0003_x.surql":    {Data: []byte("ignored")},
+	}
+
+	migrations, err := Load(fsys, "migrations")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 3, len(migrations))
+
+	assert.Equal(t, uint64(1), migrations[0].Version)
+	assert.Equal(t, "create_user", migrations[0].Name)
+	assert.Equal(t, "DEFINE TABLE user SCHEMAFULL;", migrations[0].Query)
+	assert.Equal(t, checksum("DEFINE TABLE user SCHEMAFULL;"), migrations[0].Checksum)
+	assert.Equal(t, 64, len(migrations[0].Checksum))
+
+	assert.Equal(t, uint64(2), migrations[1].Version)
+	assert.Equal(t, "add_index", migrations[1].Name)
+
+	assert.Equal(t, uint64(10), migrations[2].Version)
+	assert.Equal(t, "seed", migrations[2].Name)
+}
+
+func TestLoadErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		files fstest.MapFS
+		err   error
+	}{
+		{
+			files: fstest.MapFS{"create_user.surql": {Data: []byte("x")}},
+			err:   ErrInvalidFileName,
+		},
+		{
+			files: fstest.MapFS{"0001.surql": {Data: []byte("x")}},
+			err:   ErrInvalidFileName,
+		},
+		{
+			files: fstest.MapFS{"0000_zero.surql": {Data: []byte("x")}},
+			err:   ErrInvalidFileName,
+		},
+		{
+			files: fstest.MapFS{"-1_negative.surql": {Data: []byte("x")}},
+			err:   ErrInvalidFileName,
+		},
+		{
+			files: fstest.MapFS{
+				"0001_a.surql": {Data: []byte("x")},
+				"1_b.surql":    {Data: []byte("y")},
+			},
+			err: ErrDuplicateVersion,
+		},
+		{
+			files: fstest.MapFS{"0001_empty.surql": {Data: []byte(" \n")}},
+			err:   ErrEmptyMigration,
+		},
+		{
+			files: fstest.MapFS{"0001_tx.surql": {Data: []byte("BEGIN;\nDEFINE TABLE user;\nCOMMIT;")}},
+			err:   ErrInvalidMigration,
+		},
+		{
+			files: fstest.MapFS{"0001_tx.surql": {Data: []byte("DEFINE TABLE user; -- done\n commit transaction")}},
+			err:   ErrInvalidMigration,
+		},
+		{
+			files: fstest.MapFS{"0001_tx.surql": {Data: []byte("CREATE user;\nCANCEL TRANSACTION;")}},
+			err:   ErrInvalidMigration,
+		},
+		{
+			files: fstest.MapFS{"0001_open.surql": {Data: []byte("CREATE user SET name = 'open")}},
+			err:   ErrInvalidMigration,
+		},
+	}
+
+	for _, test := range tests {
+		_, err := Load(test.files, ".")
+		assert.Check(t, errors.Is(err, test.err), "expected %v, got %v", test.err, err)
+	}
+
+	_, err := Load(fstest.MapFS{}, "missing")
+	assert.Check(t, err != nil)
+}
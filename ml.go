@@ -0,0 +1,195 @@
+package sdbc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+const (
+	pathMLImport = "/ml/import"
+	pathMLExport = "/ml/export"
+
+	modelPrefix = "ml::"
+)
+
+var (
+	regexModelVersion = regexp.MustCompile(`^[A-Za-z0-9_.+-]+$`)
+
+	ErrInvalidModelName    = errors.New("invalid model name")
+	ErrInvalidModelVersion = errors.New("invalid model version")
+	ErrInvalidModelInput   = errors.New("invalid model input")
+)
+
+// ImportModel uploads a SurrealML model file (.surml) read from the reader
+// to the selected database, using the HTTP endpoint of the server. The name
+// and version of the model are taken from the file.
+func (c *Client) ImportModel(ctx context.Context, reader io.Reader) error {
+	req, err := c.newHTTPRequest(ctx, http.MethodPost, pathMLImport, reader)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/octet-stream")
+
+	res, err := c.doHTTP(req)
+	if err != nil {
+		return fmt.Errorf("could not import model: %w", err)
+	}
+
+	defer res.Body.Close()
+
+	if _, err := io.Copy(io.Discard, res.Body); err != nil {
+		return fmt.Errorf("could not read import response: %w", err)
+	}
+
+	return nil
+}
+
+// ExportModel writes the SurrealML model file (.surml) with the given name
+// (with or without the ml:: prefix) and version to the writer, using the
+// HTTP endpoint of the server.
+func (c *Client) ExportModel(ctx context.Context, name, version string, writer io.Writer) error {
+	name, err := modelName(name, version)
+	if err != nil {
+		return err
+	}
+
+	path := pathMLExport + "/" + strings.TrimPrefix(name, modelPrefix) + "/" + version
+
+	req, err := c.newHTTPRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := c.doHTTP(req)
+	if err != nil {
+		return fmt.Errorf("could not export model: %w", err)
+	}
+
+	defer res.Body.Close()
+
+	if _, err := io.Copy(writer, res.Body); err != nil {
+		return fmt.Errorf("could not read model: %w", err)
+	}
+
+	return nil
+}
+
+// RunModel computes the output of the machine learning model with the given
+// name (with or without the ml:: prefix) and version using Run. The input is
+// either a single number, a map of named numbers (e.g. map[string]float64)
+// or a slice or array of numbers, which may be nested as long as all slices
+// of the same level have the same length (e.g. [][]float32 for a matrix).
+// Invalid inputs are rejected with ErrInvalidModelInput before the model is run.
+// It requires SurrealDB v2.0.0 or later, otherwise ErrUnsupportedByServer is returned.
+func (c *Client) RunModel(ctx context.Context, name, version string, input any) ([]byte, error) {
+	name, err := modelName(name, version)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := modelInputShape(reflect.ValueOf(input), true); err != nil {
+		return nil, err
+	}
+
+	return c.Run(ctx, name, &version, []any{input})
+}
+
+// modelName validates the name and version of a
+// model and returns the name with the ml:: prefix.
+func modelName(name, version string) (string, error) {
+	name = strings.TrimPrefix(name, modelPrefix)
+
+	if !regexName.MatchString(name) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidModelName, name)
+	}
+
+	if !regexModelVersion.MatchString(version) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidModelVersion, version)
+	}
+
+	return modelPrefix + name, nil
+}
+
+// modelInputShape returns the shape of the input (e.g. [2 3] for a 2x3 matrix).
+// Maps of named numbers are only allowed at the top level.
+func modelInputShape(val reflect.Value, top bool) ([]int, error) {
+	if !val.IsValid() {
+		return nil, fmt.Errorf("%w: input is nil", ErrInvalidModelInput)
+	}
+
+	switch val.Kind() {
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return nil, nil
+
+	case reflect.Interface, reflect.Pointer:
+		return modelInputShape(val.Elem(), top)
+
+	case reflect.Map:
+		if !top {
+			return nil, fmt.Errorf("%w: named inputs must not be nested", ErrInvalidModelInput)
+		}
+
+		if val.Type().Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("%w: names must be strings, got %s", ErrInvalidModelInput, val.Type().Key())
+		}
+
+		if val.Len() == 0 {
+			return nil, fmt.Errorf("%w: no named inputs", ErrInvalidModelInput)
+		}
+
+		iter := val.MapRange()
+		for iter.Next() {
+			shape, err := modelInputShape(iter.Value(), false)
+			if err != nil {
+				return nil, fmt.Errorf("input %q: %w", iter.Key().String(), err)
+			}
+
+			if len(shape) > 0 {
+				return nil, fmt.Errorf("%w: input %q must be a single number", ErrInvalidModelInput, iter.Key().String())
+			}
+		}
+
+		return []int{val.Len()}, nil
+
+	case reflect.Slice, reflect.Array:
+		if val.Type().Elem().Kind() == reflect.Uint8 {
+			// byte lists are encoded as a byte string instead of a list of numbers
+			return nil, fmt.Errorf("%w: unsupported type %s, use a list of int instead", ErrInvalidModelInput, val.Type())
+		}
+
+		if val.Len() == 0 {
+			return nil, fmt.Errorf("%w: empty list", ErrInvalidModelInput)
+		}
+
+		var inner []int
+
+		for index := range val.Len() {
+			shape, err := modelInputShape(val.Index(index), false)
+			if err != nil {
+				return nil, fmt.Errorf("index %d: %w", index, err)
+			}
+
+			if index > 0 && !slices.Equal(shape, inner) {
+				return nil, fmt.Errorf("%w: index %d: shape %v differs from %v", ErrInvalidModelInput, index, shape, inner)
+			}
+
+			inner = shape
+		}
+
+		return append([]int{val.Len()}, inner...), nil
+
+	default:
+		return nil, fmt.Errorf("%w: unsupported type %s", ErrInvalidModelInput, val.Type())
+	}
+}
@@ -0,0 +1,126 @@
+package sdbc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestImportExportModel(t *testing.T) {
+	t.Parallel()
+
+	var (
+		method, path, contentType string
+		body                      []byte
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, path, contentType = r.Method, r.URL.Path, r.Header.Get("Content-Type")
+		body, _ = io.ReadAll(r.Body)
+
+		_, _ = w.Write([]byte("surml"))
+	}))
+	defer server.Close()
+
+	client := newHTTPTestClient(server, SemVer{Major: 2})
+
+	if err := client.ImportModel(context.Background(), strings.NewReader("model")); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, http.MethodPost, method)
+	assert.Equal(t, pathMLImport, path)
+	assert.Equal(t, "application/octet-stream", contentType)
+	assert.Equal(t, "model", string(body))
+
+	var model bytes.Buffer
+
+	if err := client.ExportModel(context.Background(), "ml::house_price", "0.3.0", &model); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, http.MethodGet, method)
+	assert.Equal(t, "/ml/export/house_price/0.3.0", path)
+	assert.Equal(t, "surml", model.String())
+
+	err := client.ExportModel(context.Background(), "house/price", "0.3.0", io.Discard)
+	assert.Check(t, errors.Is(err, ErrInvalidModelName))
+
+	err = client.ExportModel(context.Background(), "house_price", "../0.3.0", io.Discard)
+	assert.Check(t, errors.Is(err, ErrInvalidModelVersion))
+}
+
+func TestRunModelInvalidInput(t *testing.T) {
+	t.Parallel()
+
+	// The input is validated before the (missing) connection is used.
+	client := &Client{version: SemVer{Major: 2}}
+
+	_, err := client.RunModel(context.Background(), "house_price", "0.3.0", [][]float64{{1, 2}, {3}})
+	assert.Check(t, errors.Is(err, ErrInvalidModelInput))
+
+	_, err = client.RunModel(context.Background(), "house price", "0.3.0", 1)
+	assert.Check(t, errors.Is(err, ErrInvalidModelName))
+
+	_, err = client.RunModel(context.Background(), "house_price", "", 1)
+	assert.Check(t, errors.Is(err, ErrInvalidModelVersion))
+}
+
+func TestModelInputShape(t *testing.T) {
+	t.Parallel()
+
+	value := 1.5
+
+	tests := []struct {
+		input any
+		shape []int
+	}{
+		{input: 1, shape: nil},
+		{input: &value, shape: nil},
+		{input: []float64{1, 2, 3}, shape: []int{3}},
+		{input: [2]int32{1, 2}, shape: []int{2}},
+		{input: [][]float32{{1, 2, 3}, {4, 5, 6}}, shape: []int{2, 3}},
+		{input: []any{1, 2.5, uint8(3)}, shape: []int{3}},
+		{input: map[string]float64{"squarefoot": 500, "num_floors": 1}, shape: []int{2}},
+		{input: map[string]any{"squarefoot": 500, "num_floors": 1.0}, shape: []int{2}},
+	}
+
+	for _, test := range tests {
+		shape, err := modelInputShape(reflect.ValueOf(test.input), true)
+		if err != nil {
+			t.Fatalf("%v: %v", test.input, err)
+		}
+
+		assert.DeepEqual(t, test.shape, shape)
+	}
+
+	invalid := []any{
+		nil,
+		"1",
+		[]float64{},
+		[]string{"a"},
+		[][]float64{{1, 2}, {3}},
+		[]any{1, []int{2}},
+		map[string]float64{},
+		map[int]float64{1: 1},
+		map[string][]float64{"a": {1}},
+		[]map[string]float64{{"a": 1}},
+		map[string]any{"a": nil},
+		[]uint8{1, 2},
+		[2]byte{1, 2},
+		[][]byte{{1}, {2}},
+	}
+
+	for _, input := range invalid {
+		_, err := modelInputShape(reflect.ValueOf(input), true)
+		assert.Check(t, errors.Is(err, ErrInvalidModelInput), "%#v: %v", input, err)
+	}
+}
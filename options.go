@@ -4,6 +4,8 @@ import (
 	"context"
 	"log/slog"
 	"net/http"
+	"reflect"
+	"strings"
 	"time"
 )
 
@@ -17,6 +19,22 @@ type options struct {
 	logger     *slog.Logger
 	readLimit  int64
 	httpClient HTTPClient
+	tags       []decodeTag
+	minVersion SemVer
+	liveOwner  string
+	liveLet    bool
+	tracer     Tracer
+	metrics    Metrics
+
+	interceptors       []Interceptor
+	streamInterceptors []StreamInterceptor
+
+	slowQueries *SlowQueryOptions
+	queryStats  *queryStats
+
+	redactedVars    map[string]struct{}
+	redactedTags    []string
+	logPayloadLimit int
 }
 
 type Option func(*options)
@@ -45,14 +63,140 @@ func WithReadLimit(limit int64) Option {
 	}
 }
 
-// WithHTTPClient sets a custom http client.
-// If not set, the default http client is used.
+// WithHTTPClient sets a custom http client, which is used for the
+// HTTP endpoints of the server (e.g. Export and Import). If not set, the default http client is used.
 func WithHTTPClient(client HTTPClient) Option {
 	return func(c *options) {
 		c.httpClient = client
 	}
 }
 
+// WithTag registers a custom type for the given CBOR tag number.
+// Values with this tag are decoded into T when the target is an interface (any),
+// and values of type T are wrapped in this tag when encoded. Custom tags take
+// precedence over the tags supported by this package.
+func WithTag[T any](number uint64) Option {
+	return func(c *options) {
+		c.tags = append(c.tags, decodeTag{
+			number: number,
+			typ:    reflect.TypeFor[T](),
+			custom: true,
+		})
+	}
+}
+
+// WithMinServerVersion sets the minimum version the server must have.
+// If the server version is lower, NewClient fails with ErrUnsupportedByServer.
+func WithMinServerVersion(version SemVer) Option {
+	return func(c *options) {
+		c.minVersion = version
+	}
+}
+
+// WithLiveParamSweep sets an owner for the params defined by Live (see there).
+// When the client is created, all params left behind by a previous client
+// with the same owner are removed, e.g. after the process crashed. The owner
+// must consist of letters and digits only and must not be shared by clients
+// running at the same time, because they would remove each other's params.
+func WithLiveParamSweep(owner string) Option {
+	return func(c *options) {
+		c.liveOwner = owner
+	}
+}
+
+// WithLiveSessionVariables makes Live define its variables on the connection
+// using Let instead of defining params on the database. This requires SurrealDB
+// v2.0.0 or later, which supports variables in live queries natively. For older
+// servers, Live falls back to database params.
+func WithLiveSessionVariables() Option {
+	return func(c *options) {
+		c.liveLet = true
+	}
+}
+
+// WithTracer sets a tracer, which is notified about each request
+// and live query notification (see Tracer).
+// If not set, no tracing is done.
+func WithTracer(tracer Tracer) Option {
+	return func(c *options) {
+		c.tracer = tracer
+	}
+}
+
+// WithMetrics sets the metrics, which are recorded for the requests,
+// the websocket connection and the live query notifications (see Metrics).
+// If not set, no metrics are recorded.
+func WithMetrics(metrics Metrics) Option {
+	return func(c *options) {
+		c.metrics = metrics
+	}
+}
+
+// WithInterceptor adds an interceptor, which wraps all RPC requests (see Interceptor).
+// It can be used multiple times, the first interceptor added is the outermost one.
+func WithInterceptor(interceptor Interceptor) Option {
+	return func(c *options) {
+		c.interceptors = append(c.interceptors, interceptor)
+	}
+}
+
+// WithStreamInterceptor adds a stream interceptor, which wraps the delivery of live query
+// notifications (see StreamInterceptor). It can be used multiple times,
+// the first interceptor added is the outermost one.
+func WithStreamInterceptor(interceptor StreamInterceptor) Option {
+	return func(c *options) {
+		c.streamInterceptors = append(c.streamInterceptors, interceptor)
+	}
+}
+
+// WithSlowQueryLog enables the slow query log, which reports queries exceeding
+// the thresholds (see SlowQueryOptions). The query is reported normalised, so
+// literal values are never included, and only with the names of its variables.
+// If not set, slow queries are not reported.
+func WithSlowQueryLog(opts SlowQueryOptions) Option {
+	return func(c *options) {
+		c.slowQueries = &opts
+	}
+}
+
+// WithQueryStats enables the aggregation of statistics per normalised
+// query, which can be retrieved with Client.QueryStats.
+// If not set, no statistics are aggregated.
+func WithQueryStats() Option {
+	return func(c *options) {
+		c.queryStats = newQueryStats()
+	}
+}
+
+// WithRedactedVars registers sensitive names, whose values are never logged.
+// They apply to query variables, variables set with Let, map keys and struct
+// fields (by their encoded name), are case-insensitive and may be prefixed with $.
+// The params of signin, signup and authenticate requests are never logged anyway.
+func WithRedactedVars(names ...string) Option {
+	return func(c *options) {
+		for _, name := range names {
+			c.redactedVars[strings.ToLower(strings.TrimPrefix(name, "$"))] = struct{}{}
+		}
+	}
+}
+
+// WithRedactedTag registers a struct tag key, which marks sensitive fields
+// whose values are never logged, e.g. "secret" for `secret:"true"`.
+func WithRedactedTag(key string) Option {
+	return func(c *options) {
+		c.redactedTags = append(c.redactedTags, key)
+	}
+}
+
+// WithLogPayloadLimit sets the maximum size (in bytes) of the params and results
+// included in log records, longer payloads are truncated. A limit of 0 or less
+// disables the truncation. If not set, the default limit is 1 KB.
+func WithLogPayloadLimit(limit int) Option {
+	return func(c *options) {
+		c.logPayloadLimit = limit
+	}
+}
+
 type HTTPClient interface {
 	Do(req *http.Request) (*http.Response, error)
 }
@@ -68,6 +212,10 @@ func applyOptions(opts []Option) *options {
 		logger:     slog.New(&emptyLogHandler{}),
 		readLimit:  defaultReadLimit,
 		httpClient: http.DefaultClient,
+		metrics:    emptyMetrics{},
+
+		redactedVars:    map[string]struct{}{},
+		logPayloadLimit: defaultLogPayloadLimit,
 	}
 
 	for _, opt := range opts {
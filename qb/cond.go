@@ -0,0 +1,150 @@
+package qb
+
+import (
+	"strings"
+)
+
+// Cond is a condition used within a WHERE clause.
+type Cond interface {
+	build(p *params) (string, error)
+}
+
+// Expr creates a condition from a raw SurrealQL expression. Each placeholder
+// (?) is replaced with a variable bound to the respective argument,
+// e.g. Expr("age >= ? AND tags CONTAINS ?", 18, "admin"). Question marks
+// within literals and the operators ??, ?=, ?~ and ?: are kept as is.
+func Expr(expr string, args ...any) Cond {
+	return &exprCond{expr: expr, args: args}
+}
+
+type exprCond struct {
+	expr string
+	args []any
+}
+
+func (c *exprCond) build(p *params) (string, error) {
+	return p.expand(c.expr, c.args)
+}
+
+// Eq creates the condition field = value.
+func Eq(field string, val any) Cond {
+	return &compareCond{field: field, operator: "=", value: val}
+}
+
+// Ne creates the condition field != value.
+func Ne(field string, val any) Cond {
+	return &compareCond{field: field, operator: "!=", value: val}
+}
+
+// Gt creates the condition field > value.
+func Gt(field string, val any) Cond {
+	return &compareCond{field: field, operator: ">", value: val}
+}
+
+// Gte creates the condition field >= value.
+func Gte(field string, val any) Cond {
+	return &compareCond{field: field, operator: ">=", value: val}
+}
+
+// Lt creates the condition field < value.
+func Lt(field string, val any) Cond {
+	return &compareCond{field: field, operator: "<", value: val}
+}
+
+// Lte creates the condition field <= value.
+func Lte(field string, val any) Cond {
+	return &compareCond{field: field, operator: "<=", value: val}
+}
+
+// In creates the condition field IN value, where value is usually a slice.
+func In(field string, val any) Cond {
+	return &compareCond{field: field, operator: "IN", value: val}
+}
+
+// Contains creates the condition field CONTAINS value.
+func Contains(field string, val any) Cond {
+	return &compareCond{field: field, operator: "CONTAINS", value: val}
+}
+
+type compareCond struct {
+	field    string
+	operator string
+	value    any
+}
+
+func (c *compareCond) build(p *params) (string, error) {
+	return escapeField(c.field) + " " + c.operator + " " + p.bind(c.value), nil
+}
+
+// And combines the conditions, so that all of them must be true.
+func And(conds ...Cond) Cond {
+	return &joinCond{operator: " AND ", empty: "true", conds: conds}
+}
+
+// Or combines the conditions, so that at least one of them must be true.
+func Or(conds ...Cond) Cond {
+	return &joinCond{operator: " OR ", empty: "false", conds: conds}
+}
+
+type joinCond struct {
+	operator string
+	empty    string // result if there are no conditions
+	conds    []Cond
+}
+
+func (c *joinCond) build(p *params) (string, error) {
+	switch len(c.conds) {
+
+	case 0:
+		return c.empty, nil
+
+	case 1:
+		return c.conds[0].build(p)
+	}
+
+	parts := make([]string, len(c.conds))
+
+	for index, cond := range c.conds {
+		part, err := cond.build(p)
+		if err != nil {
+			return "", err
+		}
+
+		parts[index] = "(" + part + ")"
+	}
+
+	return strings.Join(parts, c.operator), nil
+}
+
+// Not negates the condition.
+func Not(cond Cond) Cond {
+	return &notCond{cond: cond}
+}
+
+type notCond struct {
+	cond Cond
+}
+
+func (c *notCond) build(p *params) (string, error) {
+	part, err := c.cond.build(p)
+	if err != nil {
+		return "", err
+	}
+
+	return "!(" + part + ")", nil
+}
+
+func writeWhere(builder *strings.Builder, p *params, cond Cond) error {
+	if cond == nil {
+		return nil
+	}
+
+	where, err := cond.build(p)
+	if err != nil {
+		return err
+	}
+
+	builder.WriteString(" WHERE " + where)
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package qb
+
+import (
+	"errors"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestCond(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		cond Cond
+		want string
+		vars map[string]any
+	}{
+		{cond: Eq("name", "tobie"), want: "name = $p1", vars: map[string]any{"p1": "tobie"}},
+		{cond: Ne("a", 1), want: "a != $p1", vars: map[string]any{"p1": 1}},
+		{cond: Gt("a", 1), want: "a > $p1", vars: map[string]any{"p1": 1}},
+		{cond: Gte("a", 1), want: "a >= $p1", vars: map[string]any{"p1": 1}},
+		{cond: Lt("a", 1), want: "a < $p1", vars: map[string]any{"p1": 1}},
+		{cond: Lte("a", 1), want: "a <= $p1", vars: map[string]any{"p1": 1}},
+		{cond: In("a", []int{1, 2}), want: "a IN $p1", vars: map[string]any{"p1": []int{1, 2}}},
+		{cond: Contains("tags", "x"), want: "tags CONTAINS $p1", vars: map[string]any{"p1": "x"}},
+		{cond: Eq("some field", 1), want: "`some field` = $p1", vars: map[string]any{"p1": 1}},
+		{
+			cond: And(Eq("a", 1), Or(Lt("b", 2), Not(Expr("c = ?", 3)))),
+			want: "(a = $p1) AND ((b < $p2) OR (!(c = $p3)))",
+			vars: map[string]any{"p1": 1, "p2": 2, "p3": 3},
+		},
+		{cond: And(), want: "true", vars: map[string]any{}},
+		{cond: Or(), want: "false", vars: map[string]any{}},
+		{cond: And(Eq("a", 1)), want: "a = $p1", vars: map[string]any{"p1": 1}},
+	}
+
+	for _, test := range tests {
+		p := newParams()
+
+		got, err := test.cond.build(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, test.want, got)
+		assert.DeepEqual(t, test.vars, p.vars)
+	}
+
+	_, err := And(Eq("a", 1), Expr("b = ?")).build(newParams())
+	assert.Check(t, errors.Is(err, ErrPlaceholderMismatch))
+}
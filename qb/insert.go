@@ -0,0 +1,107 @@
+package qb
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-surreal/sdbc/internal/surrealql"
+)
+
+// InsertStatement builds an INSERT statement.
+type InsertStatement struct {
+	table       string
+	relation    bool
+	ignore      bool
+	data        any
+	onDuplicate []assignment
+	ret         returnClause
+}
+
+// Insert creates an INSERT statement for the given table.
+func Insert(table string) *InsertStatement {
+	return &InsertStatement{table: table}
+}
+
+// Values sets the records to insert. It is either
+// a single record or a slice of records.
+func (s *InsertStatement) Values(data any) *InsertStatement {
+	s.data = data
+
+	return s
+}
+
+// Relation inserts relations instead of records (INSERT RELATION).
+// The records must contain the in and out fields.
+func (s *InsertStatement) Relation() *InsertStatement {
+	s.relation = true
+
+	return s
+}
+
+// Ignore skips records whose ID already exists (INSERT IGNORE).
+func (s *InsertStatement) Ignore() *InsertStatement {
+	s.ignore = true
+
+	return s
+}
+
+// OnDuplicate sets the given field of records whose ID already exists
+// (ON DUPLICATE KEY UPDATE). It can be called multiple times to set
+// multiple fields.
+func (s *InsertStatement) OnDuplicate(field string, val any) *InsertStatement {
+	s.onDuplicate = append(s.onDuplicate, assignment{field: field, value: val})
+
+	return s
+}
+
+// Return defines what the statement returns for each inserted record.
+func (s *InsertStatement) Return(mode ReturnMode) *InsertStatement {
+	s.ret.mode = mode
+
+	return s
+}
+
+// ReturnFields returns the given fields for each inserted record.
+func (s *InsertStatement) ReturnFields(fields ...string) *InsertStatement {
+	s.ret.fields = append(s.ret.fields, fields...)
+
+	return s
+}
+
+func (s *InsertStatement) Build() (string, map[string]any, error) {
+	if s.table == "" {
+		return "", nil, fmt.Errorf("%w: insert requires a table", ErrInvalidStatement)
+	}
+
+	if s.data == nil {
+		return "", nil, fmt.Errorf("%w: insert requires values", ErrInvalidStatement)
+	}
+
+	if s.ignore && len(s.onDuplicate) > 0 {
+		return "", nil, fmt.Errorf("%w: ignore and on duplicate are mutually exclusive", ErrInvalidStatement)
+	}
+
+	p := newParams()
+
+	var builder strings.Builder
+
+	builder.WriteString("INSERT ")
+
+	if s.relation {
+		builder.WriteString("RELATION ")
+	}
+
+	if s.ignore {
+		builder.WriteString("IGNORE ")
+	}
+
+	builder.WriteString("INTO " + surrealql.EscapeIdent(s.table) + " " + p.bind(s.data))
+
+	writeAssignments(&builder, p, "ON DUPLICATE KEY UPDATE", s.onDuplicate)
+
+	if err := s.ret.write(&builder); err != nil {
+		return "", nil, err
+	}
+
+	return builder.String(), p.vars, nil
+}
@@ -0,0 +1,46 @@
+package qb
+
+import (
+	"errors"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestInsert(t *testing.T) {
+	t.Parallel()
+
+	data := []any{map[string]any{"id": "tobie"}}
+
+	query, vars, err := Insert("person").Values(data).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "INSERT INTO person $p1", query)
+	assert.DeepEqual(t, map[string]any{"p1": data}, vars)
+
+	query, vars, err = Insert("person").Values(data).OnDuplicate("visits", 1).ReturnFields("id").Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "INSERT INTO person $p1 ON DUPLICATE KEY UPDATE visits = $p2 RETURN id", query)
+	assert.DeepEqual(t, map[string]any{"p1": data, "p2": 1}, vars)
+
+	query, _, err = Insert("likes").Relation().Ignore().Values(data).Return(ReturnNone).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "INSERT RELATION IGNORE INTO likes $p1 RETURN NONE", query)
+
+	for _, stmt := range []*InsertStatement{
+		Insert("").Values(data),
+		Insert("person"),
+		Insert("person").Values(data).Ignore().OnDuplicate("a", 1),
+	} {
+		_, _, err := stmt.Build()
+		assert.Check(t, errors.Is(err, ErrInvalidStatement), err)
+	}
+}
@@ -0,0 +1,205 @@
+package qb
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	kindCreate = "CREATE"
+	kindUpdate = "UPDATE"
+	kindUpsert = "UPSERT"
+	kindDelete = "DELETE"
+)
+
+// dataMode defines how the data of a statement is applied to the records.
+type dataMode string
+
+const (
+	dataContent dataMode = "CONTENT"
+	dataMerge   dataMode = "MERGE"
+	dataPatch   dataMode = "PATCH"
+	dataReplace dataMode = "REPLACE"
+)
+
+// MutateStatement builds a CREATE, UPDATE, UPSERT or DELETE statement.
+type MutateStatement struct {
+	kind     string
+	only     bool
+	targets  []any
+	mode     dataMode
+	data     any
+	set      []assignment
+	where    Cond
+	ret      returnClause
+	timeout  time.Duration
+	parallel bool
+}
+
+// Create creates a CREATE statement. Strings are treated as table names,
+// all other values (e.g. record IDs) are bound as variables.
+func Create(targets ...any) *MutateStatement {
+	return &MutateStatement{kind: kindCreate, targets: targets}
+}
+
+// Update creates an UPDATE statement. Strings are treated as table names,
+// all other values (e.g. record IDs or ranges) are bound as variables.
+func Update(targets ...any) *MutateStatement {
+	return &MutateStatement{kind: kindUpdate, targets: targets}
+}
+
+// Upsert creates an UPSERT statement. Strings are treated as table names,
+// all other values (e.g. record IDs or ranges) are bound as variables.
+func Upsert(targets ...any) *MutateStatement {
+	return &MutateStatement{kind: kindUpsert, targets: targets}
+}
+
+// Delete creates a DELETE statement. Strings are treated as table names,
+// all other values (e.g. record IDs or ranges) are bound as variables.
+func Delete(targets ...any) *MutateStatement {
+	return &MutateStatement{kind: kindDelete, targets: targets}
+}
+
+// Only returns a single record instead of an array of records.
+func (s *MutateStatement) Only() *MutateStatement {
+	s.only = true
+
+	return s
+}
+
+// Content replaces the records with the given data (CONTENT).
+func (s *MutateStatement) Content(data any) *MutateStatement {
+	s.mode, s.data = dataContent, data
+
+	return s
+}
+
+// Merge merges the given data into the records (MERGE).
+func (s *MutateStatement) Merge(data any) *MutateStatement {
+	s.mode, s.data = dataMerge, data
+
+	return s
+}
+
+// Patch applies the given JSON patches to the records (PATCH).
+func (s *MutateStatement) Patch(patches any) *MutateStatement {
+	s.mode, s.data = dataPatch, patches
+
+	return s
+}
+
+// Replace replaces the records with the given data (REPLACE).
+func (s *MutateStatement) Replace(data any) *MutateStatement {
+	s.mode, s.data = dataReplace, data
+
+	return s
+}
+
+// Set sets the given field to the value (SET).
+// It can be called multiple times to set multiple fields.
+func (s *MutateStatement) Set(field string, val any) *MutateStatement {
+	s.set = append(s.set, assignment{field: field, value: val})
+
+	return s
+}
+
+// Where sets the condition of the statement.
+// It is not supported for CREATE statements.
+func (s *MutateStatement) Where(cond Cond) *MutateStatement {
+	s.where = cond
+
+	return s
+}
+
+// Return defines what the statement returns for each affected record.
+func (s *MutateStatement) Return(mode ReturnMode) *MutateStatement {
+	s.ret.mode = mode
+
+	return s
+}
+
+// ReturnFields returns the given fields for each affected record.
+func (s *MutateStatement) ReturnFields(fields ...string) *MutateStatement {
+	s.ret.fields = append(s.ret.fields, fields...)
+
+	return s
+}
+
+// Timeout cancels the statement on the server if it takes longer.
+func (s *MutateStatement) Timeout(timeout time.Duration) *MutateStatement {
+	s.timeout = timeout
+
+	return s
+}
+
+// Parallel processes the targets of the statement in parallel.
+func (s *MutateStatement) Parallel() *MutateStatement {
+	s.parallel = true
+
+	return s
+}
+
+func (s *MutateStatement) Build() (string, map[string]any, error) {
+	if err := s.validate(); err != nil {
+		return "", nil, err
+	}
+
+	p := newParams()
+
+	var builder strings.Builder
+
+	builder.WriteString(s.kind + " ")
+
+	if s.only {
+		builder.WriteString("ONLY ")
+	}
+
+	builder.WriteString(p.targets(s.targets))
+
+	if s.mode != "" {
+		builder.WriteString(" " + string(s.mode) + " " + p.bind(s.data))
+	}
+
+	writeAssignments(&builder, p, "SET", s.set)
+
+	if err := writeWhere(&builder, p, s.where); err != nil {
+		return "", nil, err
+	}
+
+	if err := s.ret.write(&builder); err != nil {
+		return "", nil, err
+	}
+
+	writeTimeout(&builder, s.timeout)
+
+	if s.parallel {
+		builder.WriteString(" PARALLEL")
+	}
+
+	return builder.String(), p.vars, nil
+}
+
+func (s *MutateStatement) validate() error {
+	if len(s.targets) == 0 {
+		return fmt.Errorf("%w: %s requires at least one target", ErrInvalidStatement, strings.ToLower(s.kind))
+	}
+
+	if s.mode != "" && len(s.set) > 0 {
+		return fmt.Errorf("%w: data and set are mutually exclusive", ErrInvalidStatement)
+	}
+
+	if s.kind == kindCreate && s.where != nil {
+		return fmt.Errorf("%w: create does not support where", ErrInvalidStatement)
+	}
+
+	if s.kind == kindCreate && s.mode != "" && s.mode != dataContent {
+		return fmt.Errorf("%w: create only supports content", ErrInvalidStatement)
+	}
+
+	if s.kind == kindDelete && (s.mode != "" || len(s.set) > 0) {
+		return fmt.Errorf("%w: delete does not support data", ErrInvalidStatement)
+	}
+
+	return nil
+}
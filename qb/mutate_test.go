@@ -0,0 +1,82 @@
+package qb
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestMutate(t *testing.T) {
+	t.Parallel()
+
+	data := map[string]any{"name": "tobie"}
+
+	tests := []struct {
+		stmt *MutateStatement
+		want string
+		vars map[string]any
+	}{
+		{
+			stmt: Create("person").Content(data).Return(ReturnNone).Timeout(time.Second),
+			want: "CREATE person CONTENT $p1 RETURN NONE TIMEOUT 1s",
+			vars: map[string]any{"p1": data},
+		},
+		{
+			stmt: Create(recordID("person:tobie")).Only().Set("name", "tobie").Set("stats.count", 1),
+			want: "CREATE ONLY $p1 SET name = $p2, stats.count = $p3",
+			vars: map[string]any{"p1": recordID("person:tobie"), "p2": "tobie", "p3": 1},
+		},
+		{
+			stmt: Update("person").Merge(data).Where(Eq("age", 18)).ReturnFields("name", "age"),
+			want: "UPDATE person MERGE $p1 WHERE age = $p2 RETURN name, age",
+			vars: map[string]any{"p1": data, "p2": 18},
+		},
+		{
+			stmt: Update("person").Patch([]any{}).Return(ReturnDiff).Parallel(),
+			want: "UPDATE person PATCH $p1 RETURN DIFF PARALLEL",
+			vars: map[string]any{"p1": []any{}},
+		},
+		{
+			stmt: Upsert(recordID("person:tobie")).Replace(data).Return(ReturnAfter),
+			want: "UPSERT $p1 REPLACE $p2 RETURN AFTER",
+			vars: map[string]any{"p1": recordID("person:tobie"), "p2": data},
+		},
+		{
+			stmt: Delete("person").Where(Lt("age", 18)).Return(ReturnBefore),
+			want: "DELETE person WHERE age < $p1 RETURN BEFORE",
+			vars: map[string]any{"p1": 18},
+		},
+	}
+
+	for _, test := range tests {
+		query, vars, err := test.stmt.Build()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, test.want, query)
+		assert.DeepEqual(t, test.vars, vars)
+	}
+}
+
+func TestMutateInvalid(t *testing.T) {
+	t.Parallel()
+
+	invalid := []*MutateStatement{
+		Update(),
+		Update("person").Content(1).Set("a", 1),
+		Create("person").Where(Eq("a", 1)),
+		Create("person").Merge(1),
+		Delete("person").Content(1),
+		Delete("person").Set("a", 1),
+		Update("person").Return(ReturnNone).ReturnFields("a"),
+		Update("person").Return("ALL"),
+	}
+
+	for _, stmt := range invalid {
+		_, _, err := stmt.Build()
+		assert.Check(t, errors.Is(err, ErrInvalidStatement), err)
+	}
+}
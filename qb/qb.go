@@ -0,0 +1,234 @@
+// Package qb provides a fluent builder for SurrealQL statements.
+//
+// Values are never written into the query itself, but bound as variables
+// with generated names ($p1, $p2, ...). Identifiers like table and field
+// names are escaped if necessary. The result of Build can be passed to
+// Client.Query directly. Select statements can be passed to Client.Live
+// as well, which prepends the LIVE keyword itself.
+package qb
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-surreal/sdbc/internal/surrealql"
+)
+
+const paramPrefix = "p"
+
+// operatorSuffixes contains the characters that form an operator
+// when following a question mark, e.g. ?? or ?=.
+const operatorSuffixes = "?=~:"
+
+var (
+	ErrPlaceholderMismatch = errors.New("number of placeholders and arguments does not match")
+	ErrInvalidStatement    = errors.New("invalid statement")
+)
+
+// Statement is implemented by all statements of this package.
+type Statement interface {
+	// Build returns the query and the variables referenced by it.
+	Build() (string, map[string]any, error)
+}
+
+// ReturnMode defines what a statement returns for each affected record.
+type ReturnMode string
+
+const (
+	ReturnNone   ReturnMode = "NONE"
+	ReturnBefore ReturnMode = "BEFORE"
+	ReturnAfter  ReturnMode = "AFTER"
+	ReturnDiff   ReturnMode = "DIFF"
+)
+
+// Direction defines the sort order of ORDER BY.
+type Direction string
+
+const (
+	Asc  Direction = "ASC"
+	Desc Direction = "DESC"
+)
+
+//
+// -- PARAMS
+//
+
+// params collects the variables bound while building a statement.
+type params struct {
+	vars map[string]any
+}
+
+func newParams() *params {
+	return &params{vars: make(map[string]any)}
+}
+
+// bind adds the value as a new variable and returns its reference (e.g. $p1).
+func (p *params) bind(val any) string {
+	name := paramPrefix + strconv.Itoa(len(p.vars)+1)
+	p.vars[name] = val
+
+	return "$" + name
+}
+
+// expand replaces each placeholder (?) with a reference to a bound variable.
+// Question marks within string literals, escaped identifiers and comments
+// are kept as is, as are the operators ??, ?=, ?~ and ?:.
+func (p *params) expand(expr string, args []any) (string, error) {
+	tokens, err := surrealql.Lex(expr)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidStatement, err)
+	}
+
+	var (
+		builder strings.Builder
+		used    int
+	)
+
+	for _, tok := range tokens {
+		text := expr[tok.Start:tok.End]
+
+		if tok.Kind != surrealql.TokenOther {
+			builder.WriteString(text)
+
+			continue
+		}
+
+		for index := 0; index < len(text); index++ {
+			switch {
+
+			case text[index] != '?':
+				builder.WriteByte(text[index])
+
+			case index+1 < len(text) && strings.IndexByte(operatorSuffixes, text[index+1]) >= 0:
+				builder.WriteString(text[index : index+2])
+				index++
+
+			case used >= len(args):
+				return "", fmt.Errorf("%w: %q", ErrPlaceholderMismatch, expr)
+
+			default:
+				builder.WriteString(p.bind(args[used]))
+				used++
+			}
+		}
+	}
+
+	if used != len(args) {
+		return "", fmt.Errorf("%w: %q", ErrPlaceholderMismatch, expr)
+	}
+
+	return builder.String(), nil
+}
+
+// target writes the target of a statement. Strings are treated as table
+// names, all other values (e.g. record IDs) are bound as variables.
+func (p *params) target(target any) string {
+	if table, ok := target.(string); ok {
+		return surrealql.EscapeIdent(table)
+	}
+
+	return p.bind(target)
+}
+
+func (p *params) targets(targets []any) string {
+	parts := make([]string, len(targets))
+	for index, target := range targets {
+		parts[index] = p.target(target)
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+//
+// -- ESCAPING
+//
+
+// escapeField escapes each part of a (dot separated) field path, e.g. stats.count.
+// The wildcard (*) is kept as is, so that paths like friends.*.name are possible.
+func escapeField(field string) string {
+	parts := strings.Split(field, ".")
+
+	for index, part := range parts {
+		if part != "*" {
+			parts[index] = surrealql.EscapeIdent(part)
+		}
+	}
+
+	return strings.Join(parts, ".")
+}
+
+func escapeFields(fields []string) string {
+	parts := make([]string, len(fields))
+	for index, field := range fields {
+		parts[index] = escapeField(field)
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+//
+// -- CLAUSES
+//
+
+// writeTimeout writes the TIMEOUT clause, if the timeout is set.
+func writeTimeout(builder *strings.Builder, timeout time.Duration) {
+	if timeout <= 0 {
+		return
+	}
+
+	builder.WriteString(" TIMEOUT " + surrealql.FormatDuration(timeout))
+}
+
+// returnClause holds the RETURN clause of a statement.
+type returnClause struct {
+	mode   ReturnMode
+	fields []string
+}
+
+func (r returnClause) write(builder *strings.Builder) error {
+	switch {
+
+	case r.mode != "" && len(r.fields) > 0:
+		return fmt.Errorf("%w: return mode and fields are mutually exclusive", ErrInvalidStatement)
+
+	case len(r.fields) > 0:
+		builder.WriteString(" RETURN " + escapeFields(r.fields))
+
+	case r.mode != "":
+		switch r.mode {
+
+		case ReturnNone, ReturnBefore, ReturnAfter, ReturnDiff:
+			builder.WriteString(" RETURN " + string(r.mode))
+
+		default:
+			return fmt.Errorf("%w: unknown return mode %q", ErrInvalidStatement, r.mode)
+		}
+	}
+
+	return nil
+}
+
+// assignment is a single field assignment of a SET clause.
+type assignment struct {
+	field string
+	value any
+}
+
+func writeAssignments(builder *strings.Builder, p *params, keyword string, assignments []assignment) {
+	if len(assignments) == 0 {
+		return
+	}
+
+	builder.WriteString(" " + keyword + " ")
+
+	for index, assign := range assignments {
+		if index > 0 {
+			builder.WriteString(", ")
+		}
+
+		builder.WriteString(escapeField(assign.field) + " = " + p.bind(assign.value))
+	}
+}
@@ -0,0 +1,79 @@
+package qb
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestExpand(t *testing.T) {
+	t.Parallel()
+
+	p := newParams()
+
+	expr, err := p.expand("a = ? AND b = '?' AND `c?` = ? AND ⟨d?⟩ = \"?\"", []any{1, 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "a = $p1 AND b = '?' AND `c?` = $p2 AND ⟨d?⟩ = \"?\"", expr)
+	assert.DeepEqual(t, map[string]any{"p1": 1, "p2": 2}, p.vars)
+
+	p = newParams()
+
+	expr, err = p.expand("a ?? ? AND b ?= ? AND c ?~ ? AND (d ?: ?) AND e=?", []any{1, 2, 3, 4, 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "a ?? $p1 AND b ?= $p2 AND c ?~ $p3 AND (d ?: $p4) AND e=$p5", expr)
+	assert.Equal(t, 5, len(p.vars))
+
+	p = newParams()
+
+	expr, err = p.expand(`a = 'it\'s ?' AND b = "say \"?\"" AND c = ? -- ?`, []any{1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, `a = 'it\'s ?' AND b = "say \"?\"" AND c = $p1 -- ?`, expr)
+
+	_, err = newParams().expand("a = 'open ?", []any{1})
+	assert.Check(t, errors.Is(err, ErrInvalidStatement))
+
+	_, err = newParams().expand("a = ?", nil)
+	assert.Check(t, errors.Is(err, ErrPlaceholderMismatch))
+
+	_, err = newParams().expand("a = ?", []any{1, 2})
+	assert.Check(t, errors.Is(err, ErrPlaceholderMismatch))
+}
+
+func TestEscape(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "stats.count", escapeField("stats.count"))
+	assert.Equal(t, "friends.*.`first name`", escapeField("friends.*.first name"))
+}
+
+func TestTimeout(t *testing.T) {
+	t.Parallel()
+
+	tests := map[time.Duration]string{
+		0:                       "",
+		5 * time.Second:         " TIMEOUT 5s",
+		1500 * time.Millisecond: " TIMEOUT 1s500ms",
+		3 * time.Microsecond:    " TIMEOUT 3µs",
+		7:                       " TIMEOUT 7ns",
+	}
+
+	for timeout, want := range tests {
+		var builder strings.Builder
+
+		writeTimeout(&builder, timeout)
+
+		assert.Equal(t, want, builder.String())
+	}
+}
@@ -0,0 +1,107 @@
+package qb
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-surreal/sdbc/internal/surrealql"
+)
+
+// RelateStatement builds a RELATE statement.
+type RelateStatement struct {
+	only    bool
+	from    any
+	edge    string
+	to      any
+	data    any
+	set     []assignment
+	ret     returnClause
+	timeout time.Duration
+}
+
+// Relate creates a RELATE statement (from->edge->to). The records
+// (e.g. record IDs or arrays of them) are bound as variables,
+// while the edge is the name of the relation table.
+func Relate(from any, edge string, to any) *RelateStatement {
+	return &RelateStatement{from: from, edge: edge, to: to}
+}
+
+// Only returns a single record instead of an array of records.
+func (s *RelateStatement) Only() *RelateStatement {
+	s.only = true
+
+	return s
+}
+
+// Content sets the data of the relation (CONTENT).
+func (s *RelateStatement) Content(data any) *RelateStatement {
+	s.data = data
+
+	return s
+}
+
+// Set sets the given field of the relation to the value (SET).
+// It can be called multiple times to set multiple fields.
+func (s *RelateStatement) Set(field string, val any) *RelateStatement {
+	s.set = append(s.set, assignment{field: field, value: val})
+
+	return s
+}
+
+// Return defines what the statement returns for each created relation.
+func (s *RelateStatement) Return(mode ReturnMode) *RelateStatement {
+	s.ret.mode = mode
+
+	return s
+}
+
+// ReturnFields returns the given fields for each created relation.
+func (s *RelateStatement) ReturnFields(fields ...string) *RelateStatement {
+	s.ret.fields = append(s.ret.fields, fields...)
+
+	return s
+}
+
+// Timeout cancels the statement on the server if it takes longer.
+func (s *RelateStatement) Timeout(timeout time.Duration) *RelateStatement {
+	s.timeout = timeout
+
+	return s
+}
+
+func (s *RelateStatement) Build() (string, map[string]any, error) {
+	if s.from == nil || s.to == nil || s.edge == "" {
+		return "", nil, fmt.Errorf("%w: relate requires from, edge and to", ErrInvalidStatement)
+	}
+
+	if s.data != nil && len(s.set) > 0 {
+		return "", nil, fmt.Errorf("%w: content and set are mutually exclusive", ErrInvalidStatement)
+	}
+
+	p := newParams()
+
+	var builder strings.Builder
+
+	builder.WriteString("RELATE ")
+
+	if s.only {
+		builder.WriteString("ONLY ")
+	}
+
+	builder.WriteString(p.bind(s.from) + "->" + surrealql.EscapeIdent(s.edge) + "->" + p.bind(s.to))
+
+	if s.data != nil {
+		builder.WriteString(" CONTENT " + p.bind(s.data))
+	}
+
+	writeAssignments(&builder, p, "SET", s.set)
+
+	if err := s.ret.write(&builder); err != nil {
+		return "", nil, err
+	}
+
+	writeTimeout(&builder, s.timeout)
+
+	return builder.String(), p.vars, nil
+}
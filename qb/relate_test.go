@@ -0,0 +1,38 @@
+package qb
+
+import (
+	"errors"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestRelate(t *testing.T) {
+	t.Parallel()
+
+	from, to := recordID("person:tobie"), recordID("article:surreal")
+
+	query, vars, err := Relate(from, "wrote", to).Set("time.written", "now").Return(ReturnNone).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "RELATE $p1->wrote->$p2 SET time.written = $p3 RETURN NONE", query)
+	assert.DeepEqual(t, map[string]any{"p1": from, "p2": to, "p3": "now"}, vars)
+
+	query, _, err = Relate(from, "likes to", to).Only().Content(map[string]any{}).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "RELATE ONLY $p1->`likes to`->$p2 CONTENT $p3", query)
+
+	for _, stmt := range []*RelateStatement{
+		Relate(nil, "wrote", to),
+		Relate(from, "", to),
+		Relate(from, "wrote", to).Content(1).Set("a", 1),
+	} {
+		_, _, err := stmt.Build()
+		assert.Check(t, errors.Is(err, ErrInvalidStatement), err)
+	}
+}
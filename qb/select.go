@@ -0,0 +1,285 @@
+package qb
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SelectStatement builds a SELECT statement.
+type SelectStatement struct {
+	fields   []string
+	exprs    []exprCond
+	value    bool
+	only     bool
+	from     []any
+	where    Cond
+	split    []string
+	group    []string
+	groupAll bool
+	order    []order
+	random   bool
+	limit    int
+	start    int
+	fetch    []string
+	timeout  time.Duration
+	parallel bool
+}
+
+type order struct {
+	field     string
+	direction Direction
+}
+
+// Select creates a SELECT statement for the given fields.
+// If no fields are given, all fields (*) are selected.
+func Select(fields ...string) *SelectStatement {
+	return &SelectStatement{fields: fields}
+}
+
+// SelectValue creates a SELECT VALUE statement for the given field,
+// which returns the values of the field instead of objects.
+func SelectValue(field string) *SelectStatement {
+	return &SelectStatement{fields: []string{field}, value: true}
+}
+
+// Expr adds a raw SurrealQL expression to the selected fields,
+// e.g. Expr("count() AS total") or Expr("age >= ? AS adult", 18).
+// Placeholders (?) are replaced with variables bound to the arguments.
+func (s *SelectStatement) Expr(expr string, args ...any) *SelectStatement {
+	s.exprs = append(s.exprs, exprCond{expr: expr, args: args})
+
+	return s
+}
+
+// From sets the targets of the statement. Strings are treated as table names,
+// all other values (e.g. record IDs or ranges) are bound as variables.
+func (s *SelectStatement) From(targets ...any) *SelectStatement {
+	s.from = append(s.from, targets...)
+
+	return s
+}
+
+// Only selects a single record instead of an array of records.
+func (s *SelectStatement) Only() *SelectStatement {
+	s.only = true
+
+	return s
+}
+
+// Where sets the condition of the statement.
+// Multiple conditions can be combined with And or Or.
+func (s *SelectStatement) Where(cond Cond) *SelectStatement {
+	s.where = cond
+
+	return s
+}
+
+// Split splits the results by the values of the given array fields.
+func (s *SelectStatement) Split(fields ...string) *SelectStatement {
+	s.split = append(s.split, fields...)
+
+	return s
+}
+
+// GroupBy groups the results by the given fields.
+func (s *SelectStatement) GroupBy(fields ...string) *SelectStatement {
+	s.group = append(s.group, fields...)
+
+	return s
+}
+
+// GroupAll groups all results into a single one (GROUP ALL).
+func (s *SelectStatement) GroupAll() *SelectStatement {
+	s.groupAll = true
+
+	return s
+}
+
+// OrderBy sorts the results by the given field.
+// It can be called multiple times to sort by multiple fields.
+func (s *SelectStatement) OrderBy(field string, direction Direction) *SelectStatement {
+	s.order = append(s.order, order{field: field, direction: direction})
+
+	return s
+}
+
+// OrderRandom sorts the results randomly (ORDER BY RAND()).
+func (s *SelectStatement) OrderRandom() *SelectStatement {
+	s.random = true
+
+	return s
+}
+
+// Limit limits the number of results.
+func (s *SelectStatement) Limit(limit int) *SelectStatement {
+	s.limit = limit
+
+	return s
+}
+
+// Start skips the given number of results.
+func (s *SelectStatement) Start(start int) *SelectStatement {
+	s.start = start
+
+	return s
+}
+
+// Fetch replaces the record links of the given fields with the records.
+func (s *SelectStatement) Fetch(fields ...string) *SelectStatement {
+	s.fetch = append(s.fetch, fields...)
+
+	return s
+}
+
+// Timeout cancels the statement on the server if it takes longer.
+func (s *SelectStatement) Timeout(timeout time.Duration) *SelectStatement {
+	s.timeout = timeout
+
+	return s
+}
+
+// Parallel processes the targets of the statement in parallel.
+func (s *SelectStatement) Parallel() *SelectStatement {
+	s.parallel = true
+
+	return s
+}
+
+func (s *SelectStatement) Build() (string, map[string]any, error) {
+	if len(s.from) == 0 {
+		return "", nil, fmt.Errorf("%w: select requires at least one target", ErrInvalidStatement)
+	}
+
+	if s.value && (len(s.fields) != 1 || len(s.exprs) > 0) {
+		return "", nil, fmt.Errorf("%w: select value requires exactly one field", ErrInvalidStatement)
+	}
+
+	if s.groupAll && len(s.group) > 0 {
+		return "", nil, fmt.Errorf("%w: group all and group by are mutually exclusive", ErrInvalidStatement)
+	}
+
+	p := newParams()
+
+	var builder strings.Builder
+
+	builder.WriteString("SELECT ")
+
+	if s.value {
+		builder.WriteString("VALUE ")
+	}
+
+	if err := s.writeFields(&builder, p); err != nil {
+		return "", nil, err
+	}
+
+	builder.WriteString(" FROM ")
+
+	if s.only {
+		builder.WriteString("ONLY ")
+	}
+
+	builder.WriteString(p.targets(s.from))
+
+	if err := writeWhere(&builder, p, s.where); err != nil {
+		return "", nil, err
+	}
+
+	if len(s.split) > 0 {
+		builder.WriteString(" SPLIT " + escapeFields(s.split))
+	}
+
+	switch {
+
+	case s.groupAll:
+		builder.WriteString(" GROUP ALL")
+
+	case len(s.group) > 0:
+		builder.WriteString(" GROUP BY " + escapeFields(s.group))
+	}
+
+	if err := s.writeOrder(&builder); err != nil {
+		return "", nil, err
+	}
+
+	if s.limit > 0 {
+		builder.WriteString(" LIMIT " + p.bind(s.limit))
+	}
+
+	if s.start > 0 {
+		builder.WriteString(" START " + p.bind(s.start))
+	}
+
+	if len(s.fetch) > 0 {
+		builder.WriteString(" FETCH " + escapeFields(s.fetch))
+	}
+
+	writeTimeout(&builder, s.timeout)
+
+	if s.parallel {
+		builder.WriteString(" PARALLEL")
+	}
+
+	return builder.String(), p.vars, nil
+}
+
+func (s *SelectStatement) writeFields(builder *strings.Builder, p *params) error {
+	parts := make([]string, 0, len(s.fields)+len(s.exprs))
+
+	for _, field := range s.fields {
+		parts = append(parts, escapeField(field))
+	}
+
+	for _, expr := range s.exprs {
+		part, err := expr.build(p)
+		if err != nil {
+			return err
+		}
+
+		parts = append(parts, part)
+	}
+
+	if len(parts) == 0 {
+		parts = append(parts, "*")
+	}
+
+	builder.WriteString(strings.Join(parts, ", "))
+
+	return nil
+}
+
+func (s *SelectStatement) writeOrder(builder *strings.Builder) error {
+	if s.random {
+		if len(s.order) > 0 {
+			return fmt.Errorf("%w: random order cannot be combined with fields", ErrInvalidStatement)
+		}
+
+		builder.WriteString(" ORDER BY RAND()")
+
+		return nil
+	}
+
+	if len(s.order) == 0 {
+		return nil
+	}
+
+	parts := make([]string, len(s.order))
+
+	for index, item := range s.order {
+		switch item.direction {
+
+		case "":
+			parts[index] = escapeField(item.field)
+
+		case Asc, Desc:
+			parts[index] = escapeField(item.field) + " " + string(item.direction)
+
+		default:
+			return fmt.Errorf("%w: unknown direction %q", ErrInvalidStatement, item.direction)
+		}
+	}
+
+	builder.WriteString(" ORDER BY " + strings.Join(parts, ", "))
+
+	return nil
+}
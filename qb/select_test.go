@@ -0,0 +1,104 @@
+package qb
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+type recordID string
+
+func TestSelect(t *testing.T) {
+	t.Parallel()
+
+	query, vars, err := Select().From("person").Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "SELECT * FROM person", query)
+	assert.DeepEqual(t, map[string]any{}, vars)
+
+	query, vars, err = Select("name", "address.city").
+		Expr("count(friends) > ? AS popular", 10).
+		From("person", recordID("person:tobie")).
+		Where(And(Gte("age", 18), Contains("tags", "admin"))).
+		Split("emails").
+		GroupBy("address.city", "name").
+		OrderBy("name", Asc).
+		OrderBy("age", "").
+		Limit(10).
+		Start(20).
+		Fetch("friends").
+		Timeout(5 * time.Second).
+		Parallel().
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "SELECT name, address.city, count(friends) > $p1 AS popular FROM person, $p2 "+
+		"WHERE (age >= $p3) AND (tags CONTAINS $p4) SPLIT emails GROUP BY address.city, name "+
+		"ORDER BY name ASC, age LIMIT $p5 START $p6 FETCH friends TIMEOUT 5s PARALLEL", query)
+	assert.DeepEqual(t, map[string]any{
+		"p1": 10,
+		"p2": recordID("person:tobie"),
+		"p3": 18,
+		"p4": "admin",
+		"p5": 10,
+		"p6": 20,
+	}, vars)
+
+	query, _, err = SelectValue("name").From("some table").Only().GroupAll().Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "SELECT VALUE name FROM ONLY `some table` GROUP ALL", query)
+
+	query, _, err = Select().From("person").OrderRandom().Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "SELECT * FROM person ORDER BY RAND()", query)
+}
+
+func TestSelectBuildIsRepeatable(t *testing.T) {
+	t.Parallel()
+
+	stmt := Select().From("person").Where(Eq("name", "tobie"))
+
+	query1, vars1, err := stmt.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	query2, vars2, err := stmt.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, query1, query2)
+	assert.DeepEqual(t, vars1, vars2)
+}
+
+func TestSelectInvalid(t *testing.T) {
+	t.Parallel()
+
+	invalid := []*SelectStatement{
+		Select(),
+		SelectValue("a").Expr("b").From("person"),
+		Select().From("person").GroupAll().GroupBy("a"),
+		Select().From("person").OrderRandom().OrderBy("a", Asc),
+		Select().From("person").OrderBy("a", "UP"),
+		Select().From("person").Where(Expr("a = ?")),
+	}
+
+	for _, stmt := range invalid {
+		_, _, err := stmt.Build()
+		assert.Check(t, errors.Is(err, ErrInvalidStatement) || errors.Is(err, ErrPlaceholderMismatch), err)
+	}
+}
@@ -0,0 +1,557 @@
+package sdbc
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/go-surreal/sdbc/internal/surrealql"
+)
+
+const (
+	// queryStatsSamples is the number of most recent durations
+	// per query used to compute the percentiles.
+	queryStatsSamples = 1024
+
+	// queryStatsLimit is the maximum number of distinct queries tracked.
+	queryStatsLimit = 1024
+
+	invalidQuery = "(invalid query)"
+	placeholder  = "?"
+)
+
+// SlowQueryOptions configures the slow query log (see WithSlowQueryLog).
+type SlowQueryOptions struct {
+	// ServerTime is the threshold for the execution time of a single
+	// statement as reported by the server. If 0, it is not checked.
+	ServerTime time.Duration
+
+	// RoundTrip is the threshold for the time from sending the query until
+	// its response has been received. If 0, it is not checked.
+	RoundTrip time.Duration
+
+	// Handler is called for each slow query.
+	// If nil, slow queries are logged with level WARN.
+	Handler func(ctx context.Context, query SlowQuery)
+}
+
+// SlowQuery describes a query that exceeded a threshold of the slow query log.
+type SlowQuery struct {
+	// Query is the normalised query (see QueryStats.Query).
+	Query string
+
+	// Vars are the names of the variables of the query. Their values are never reported.
+	Vars []string
+
+	// Statement is the normalised slowest statement of the query, or empty
+	// if the statements could not be matched with the results of the response.
+	Statement string
+
+	// StatementTime is the execution time of the slowest statement reported by the server.
+	StatementTime time.Duration
+
+	// RoundTrip is the time from sending the query until its response has been received.
+	RoundTrip time.Duration
+}
+
+// QueryStats are the aggregated statistics of a normalised query (see WithQueryStats).
+type QueryStats struct {
+	// Query is the normalised query text. Literal strings, numbers and record
+	// ID keys are replaced by ?, comments are removed and whitespace is collapsed.
+	Query string
+
+	// Count is the number of times the query has been sent.
+	Count int
+
+	// Errors is the number of times the request failed or a statement returned an error.
+	Errors int
+
+	// P50 and P99 are percentiles of the round trip time of the query.
+	P50 time.Duration
+	P99 time.Duration
+
+	// ServerP50 and ServerP99 are percentiles of the execution time reported
+	// by the server, summed up for all statements of the query.
+	ServerP50 time.Duration
+	ServerP99 time.Duration
+}
+
+// QueryStats returns the statistics of all queries sent so far, ordered by query.
+// The percentiles are computed from the most recent executions of each query.
+// It returns nil if the statistics are not enabled with WithQueryStats.
+func (c *Client) QueryStats() []QueryStats {
+	if c.queryStats == nil {
+		return nil
+	}
+
+	return c.queryStats.all()
+}
+
+// queryResponse is the summary of the response of a query request.
+type queryResponse struct {
+	times []time.Duration // execution times of the statements
+	err   error           // error of the first failed statement
+}
+
+// parseQueryResponse summarizes the response of a query request.
+// It reports false if the response could not be decoded.
+func (c *Client) parseQueryResponse(res []byte) (queryResponse, bool) {
+	var results []basicResponse[cbor.RawMessage]
+
+	if err := c.unmarshal(res, &results); err != nil {
+		return queryResponse{}, false
+	}
+
+	out := queryResponse{times: make([]time.Duration, len(results))}
+
+	for index, result := range results {
+		out.times[index] = time.Duration(result.Time)
+
+		if result.Status != "OK" && out.err == nil {
+			var msg string
+
+			_ = c.unmarshal(result.Result, &msg) // the message is for information only
+
+			out.err = fmt.Errorf("%w: %s", ErrResponseNotOkay, msg)
+		}
+	}
+
+	return out, true
+}
+
+// observeQuery records the query request for the query
+// stats and reports it to the slow query log if needed.
+func (c *Client) observeQuery(ctx context.Context, req request, res []byte, err error, roundTrip time.Duration) {
+	normalized := normalizeQuery(queryText(req))
+
+	var summary queryResponse
+
+	if err == nil {
+		summary, _ = c.parseQueryResponse(res)
+	}
+
+	if c.queryStats != nil {
+		var serverTime time.Duration
+
+		for _, statementTime := range summary.times {
+			serverTime += statementTime
+		}
+
+		c.queryStats.add(normalized, roundTrip, serverTime, err != nil || summary.err != nil)
+	}
+
+	if c.slowQueries != nil {
+		c.reportSlowQuery(ctx, req, summary.times, SlowQuery{Query: normalized, RoundTrip: roundTrip})
+	}
+}
+
+// reportSlowQuery completes the slow query with the slowest of the statement
+// times and reports it to the slow query log if it exceeded a threshold.
+func (c *Client) reportSlowQuery(ctx context.Context, req request, times []time.Duration, slow SlowQuery) {
+	slowest := -1
+
+	for index, statementTime := range times {
+		if slowest < 0 || statementTime > times[slowest] {
+			slowest = index
+		}
+	}
+
+	if slowest >= 0 {
+		slow.StatementTime = times[slowest]
+
+		// The results can only be matched with the statements if there is one per statement.
+		if statements, err := splitStatements(queryText(req)); err == nil && len(statements) == len(times) {
+			slow.Statement = normalizeQuery(statements[slowest])
+		}
+	}
+
+	opts := c.slowQueries
+
+	if (opts.ServerTime <= 0 || slow.StatementTime <= opts.ServerTime) &&
+		(opts.RoundTrip <= 0 || slow.RoundTrip <= opts.RoundTrip) {
+		return
+	}
+
+	if len(req.Params) > 1 {
+		if vars, ok := req.Params[1].(map[string]any); ok {
+			slow.Vars = slices.Sorted(maps.Keys(vars))
+		}
+	}
+
+	if opts.Handler != nil {
+		opts.Handler(ctx, slow)
+
+		return
+	}
+
+	c.logger.WarnContext(ctx, "Slow query.",
+		"query", slow.Query,
+		"vars", slow.Vars,
+		"statement", slow.Statement,
+		"statement_time", slow.StatementTime,
+		"round_trip", slow.RoundTrip,
+	)
+}
+
+// queryText returns the query of a query request.
+func queryText(req request) string {
+	if len(req.Params) == 0 {
+		return ""
+	}
+
+	query, _ := req.Params[0].(string)
+
+	return query
+}
+
+// normalizeQuery replaces literal strings, numbers and record ID keys of the
+// query with a placeholder (user:⟨tobie⟩ becomes user:?), removes comments and
+// collapses whitespace, so that queries only differing in these values are
+// considered equal and no values end up in logs or metrics.
+func normalizeQuery(query string) string {
+	tokens, err := lex(query)
+	if err != nil {
+		return invalidQuery
+	}
+
+	var builder strings.Builder
+
+	for index := 0; index < len(tokens); index++ {
+		tok := tokens[index]
+		text := query[tok.Start:tok.End]
+
+		switch tok.Kind {
+
+		case surrealql.TokenSpace, surrealql.TokenComment:
+			text = " "
+
+		case surrealql.TokenString:
+			text = placeholder
+
+		case surrealql.TokenOther:
+			text = replaceLiterals(query, tok.Start, tok.End)
+
+			// the key of the record ID is a token of its own (user:⟨tobie⟩ or temperature:['London', 1])
+			if isRecordSeparator(query, tok.End-1) {
+				if next := skipRecordKey(query, tokens, index+1); next > index+1 {
+					text += placeholder
+					index = next - 1
+				}
+			}
+
+		default:
+		}
+
+		builder.WriteString(text)
+	}
+
+	return strings.Join(strings.Fields(builder.String()), " ")
+}
+
+// replaceLiterals replaces the number literals and record ID keys between start
+// and end of the query with a placeholder. The query is passed as a whole, so
+// that numbers at the start of the range can be told apart from the end of an
+// identifier. Digits of identifiers and durations (1h) are kept.
+func replaceLiterals(query string, start, end int) string {
+	var builder strings.Builder
+
+	for pos := start; pos < end; {
+		if isRecordSeparator(query, pos) {
+			if keyEnd := recordKeyEnd(query, pos+1, end); keyEnd > pos+1 {
+				builder.WriteString(":" + placeholder)
+
+				pos = keyEnd
+
+				continue
+			}
+		}
+
+		if numEnd := numberEnd(query, pos, end); numEnd > pos {
+			builder.WriteString(placeholder)
+
+			pos = numEnd
+
+			continue
+		}
+
+		builder.WriteByte(query[pos])
+
+		pos++
+	}
+
+	return builder.String()
+}
+
+// isRecordSeparator reports whether the colon at pos separates
+// the table and the key of a record ID (as opposed to time::now).
+func isRecordSeparator(query string, pos int) bool {
+	if pos < 1 || query[pos] != ':' || (pos+1 < len(query) && query[pos+1] == ':') {
+		return false
+	}
+
+	prev := query[pos-1]
+
+	return isIdentByte(prev) || prev == '`' || strings.HasSuffix(query[:pos], "⟩")
+}
+
+// recordKeyEnd returns the end of the plain record ID key (including ranges
+// like 1..=5) starting at pos, or pos if there is none.
+func recordKeyEnd(query string, pos, end int) int {
+	index := pos
+
+	if index < end && query[index] == '-' {
+		index++
+	}
+
+	index = skipIdentBytes(query, index, end)
+
+	rangeStart := index
+
+	if index < end && query[index] == '>' {
+		index++
+	}
+
+	if !strings.HasPrefix(query[index:end], "..") {
+		return rangeStart
+	}
+
+	index += len("..")
+
+	if index < end && query[index] == '=' {
+		index++
+	}
+
+	return skipIdentBytes(query, index, end)
+}
+
+// skipRecordKey returns the index of the token following the escaped, array or
+// object key of a record ID starting at the given token, or the index itself.
+func skipRecordKey(query string, tokens []surrealql.Token, index int) int {
+	if index >= len(tokens) {
+		return index
+	}
+
+	switch tok := tokens[index]; {
+
+	case tok.Kind == surrealql.TokenIdent:
+		return index + 1
+
+	case tok.Kind == surrealql.TokenOpen && query[tok.Start] != '(':
+		depth := 0
+
+		for next := index; next < len(tokens); next++ {
+			switch tokens[next].Kind {
+
+			case surrealql.TokenOpen:
+				depth++
+
+			case surrealql.TokenClose:
+				depth--
+
+				if depth == 0 {
+					return next + 1
+				}
+
+			default:
+			}
+		}
+
+		return len(tokens)
+
+	default:
+		return index
+	}
+}
+
+// numberEnd returns the end of the (optionally signed) integer or float
+// literal starting at pos, or pos if there is none. A sign only counts as
+// part of the number if it does not follow an operand, as in a-1.
+func numberEnd(query string, pos, end int) int {
+	var prev byte
+
+	if pos > 0 {
+		prev = query[pos-1]
+	}
+
+	if isIdentByte(prev) || prev == ':' || prev == '.' {
+		return pos
+	}
+
+	index := pos
+
+	if query[index] == '+' || query[index] == '-' {
+		if isOperandEnd(prev) {
+			return pos
+		}
+
+		index++
+	}
+
+	digits := skipDigits(query, index, end)
+	if digits == index {
+		return pos
+	}
+
+	index = skipExponent(query, skipFraction(query, digits, end), end)
+
+	// a number directly followed by letters is a duration (1h) or the like
+	if index < end && isIdentByte(query[index]) {
+		return pos
+	}
+
+	return index
+}
+
+// skipFraction skips the fractional part (.5) of a number, if present.
+func skipFraction(query string, pos, end int) int {
+	if pos+1 < end && query[pos] == '.' {
+		if digits := skipDigits(query, pos+1, end); digits > pos+1 {
+			return digits
+		}
+	}
+
+	return pos
+}
+
+// skipExponent skips the exponent (e3, e-3) of a number, if present.
+func skipExponent(query string, pos, end int) int {
+	if pos+1 >= end || (query[pos] != 'e' && query[pos] != 'E') {
+		return pos
+	}
+
+	exp := pos + 1
+
+	if query[exp] == '+' || query[exp] == '-' {
+		exp++
+	}
+
+	if digits := skipDigits(query, exp, end); digits > exp {
+		return digits
+	}
+
+	return pos
+}
+
+func skipDigits(query string, pos, end int) int {
+	for pos < end && query[pos] >= '0' && query[pos] <= '9' {
+		pos++
+	}
+
+	return pos
+}
+
+func skipIdentBytes(query string, pos, end int) int {
+	for pos < end && isIdentByte(query[pos]) {
+		pos++
+	}
+
+	return pos
+}
+
+func isIdentByte(char byte) bool {
+	return char < utf8.RuneSelf && surrealql.IsIdentChar(rune(char))
+}
+
+// isOperandEnd reports whether the character may end an operand,
+// so that a following sign is a binary operator.
+func isOperandEnd(char byte) bool {
+	return isIdentByte(char) || strings.IndexByte(")]}'\"`", char) >= 0 || char >= utf8.RuneSelf
+}
+
+//
+// -- STATS
+//
+
+func newQueryStats() *queryStats {
+	return &queryStats{
+		store: map[string]*queryStat{},
+	}
+}
+
+type queryStats struct {
+	mut   sync.Mutex
+	store map[string]*queryStat
+}
+
+type queryStat struct {
+	count       int
+	errors      int
+	roundTrips  []time.Duration
+	serverTimes []time.Duration
+}
+
+// add records an execution of the query. Once the limit
+// of distinct queries is reached, new queries are ignored.
+func (s *queryStats) add(query string, roundTrip, serverTime time.Duration, failed bool) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	stat, ok := s.store[query]
+	if !ok {
+		if len(s.store) >= queryStatsLimit {
+			return
+		}
+
+		stat = &queryStat{}
+		s.store[query] = stat
+	}
+
+	// The samples are used as ring buffers of the most recent executions.
+	if index := stat.count % queryStatsSamples; index < len(stat.roundTrips) {
+		stat.roundTrips[index] = roundTrip
+		stat.serverTimes[index] = serverTime
+	} else {
+		stat.roundTrips = append(stat.roundTrips, roundTrip)
+		stat.serverTimes = append(stat.serverTimes, serverTime)
+	}
+
+	stat.count++
+
+	if failed {
+		stat.errors++
+	}
+}
+
+func (s *queryStats) all() []QueryStats {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	out := make([]QueryStats, 0, len(s.store))
+
+	for _, query := range slices.Sorted(maps.Keys(s.store)) {
+		stat := s.store[query]
+
+		roundTrips := slices.Sorted(slices.Values(stat.roundTrips))
+		serverTimes := slices.Sorted(slices.Values(stat.serverTimes))
+
+		out = append(out, QueryStats{
+			Query:     query,
+			Count:     stat.count,
+			Errors:    stat.errors,
+			P50:       percentile(roundTrips, 0.5),
+			P99:       percentile(roundTrips, 0.99),
+			ServerP50: percentile(serverTimes, 0.5),
+			ServerP99: percentile(serverTimes, 0.99),
+		})
+	}
+
+	return out
+}
+
+// percentile returns the nearest-rank percentile of the sorted durations.
+func percentile(sorted []time.Duration, rank float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+
+	index := int(math.Ceil(rank*float64(len(sorted)))) - 1
+
+	return sorted[max(index, 0)]
+}
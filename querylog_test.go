@@ -0,0 +1,159 @@
+package sdbc
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestSlowQueryLog(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mut  sync.Mutex
+		slow []SlowQuery
+	)
+
+	client := newWebsocketTestClient(t, func(req request) (any, *responseError) {
+		query, _ := req.Params[0].(string)
+
+		if strings.HasPrefix(query, "SELECT") {
+			return []basicResponse[any]{
+				{Status: "OK", Time: duration(time.Millisecond)},
+			}, nil
+		}
+
+		return []basicResponse[any]{
+			{Status: "OK", Time: duration(time.Millisecond)},
+			{Status: "OK", Time: duration(50 * time.Millisecond)},
+		}, nil
+	}, WithSlowQueryLog(SlowQueryOptions{
+		ServerTime: 10 * time.Millisecond,
+		Handler: func(_ context.Context, query SlowQuery) {
+			mut.Lock()
+			defer mut.Unlock()
+
+			slow = append(slow, query)
+		},
+	}))
+
+	_, err := client.Query(context.Background(), "SELECT * FROM user WHERE name = $name", map[string]any{
+		"name": "secret",
+	})
+	assert.NilError(t, err)
+
+	query := "CREATE user SET name = $name;\nUPDATE user SET pass = 'secret';"
+
+	_, err = client.Query(context.Background(), query, map[string]any{
+		"name": "secret",
+		"age":  42,
+	})
+	assert.NilError(t, err)
+
+	mut.Lock()
+	defer mut.Unlock()
+
+	assert.Equal(t, 1, len(slow))
+	assert.Equal(t, "CREATE user SET name = $name; UPDATE user SET pass = ?;", slow[0].Query)
+	assert.DeepEqual(t, []string{"age", "name"}, slow[0].Vars)
+	assert.Equal(t, "UPDATE user SET pass = ?", slow[0].Statement)
+	assert.Equal(t, 50*time.Millisecond, slow[0].StatementTime)
+	assert.Check(t, slow[0].RoundTrip > 0)
+}
+
+func TestQueryStats(t *testing.T) {
+	t.Parallel()
+
+	client := newWebsocketTestClient(t, func(req request) (any, *responseError) {
+		query, _ := req.Params[0].(string)
+
+		if strings.Contains(query, "fail") {
+			return []basicResponse[any]{{Status: "ERR", Result: "failed"}}, nil
+		}
+
+		return []basicResponse[any]{{Status: "OK", Time: duration(time.Millisecond)}}, nil
+	}, WithQueryStats())
+
+	for _, query := range []string{
+		"SELECT * FROM user LIMIT 10",
+		"SELECT * FROM user  LIMIT 20 -- next page",
+		"SELECT * FROM user WHERE name = 'fail' LIMIT 1",
+		"CREATE user",
+	} {
+		_, err := client.Query(context.Background(), query, nil)
+		assert.NilError(t, err)
+	}
+
+	stats := client.QueryStats()
+	assert.Equal(t, 3, len(stats))
+
+	assert.Equal(t, "CREATE user", stats[0].Query)
+	assert.Equal(t, 1, stats[0].Count)
+	assert.Equal(t, 0, stats[0].Errors)
+
+	assert.Equal(t, "SELECT * FROM user LIMIT ?", stats[1].Query)
+	assert.Equal(t, 2, stats[1].Count)
+	assert.Equal(t, 0, stats[1].Errors)
+	assert.Equal(t, time.Millisecond, stats[1].ServerP50)
+	assert.Check(t, stats[1].P50 > 0 && stats[1].P50 <= stats[1].P99)
+
+	assert.Equal(t, "SELECT * FROM user WHERE name = ? LIMIT ?", stats[2].Query)
+	assert.Equal(t, 1, stats[2].Errors)
+
+	assert.Check(t, (&Client{options: applyOptions(nil)}).QueryStats() == nil)
+}
+
+func TestQueryStatsSamples(t *testing.T) {
+	t.Parallel()
+
+	stats := newQueryStats()
+
+	for index := range queryStatsSamples + 10 {
+		stats.add("SELECT", time.Duration(index), 0, false)
+	}
+
+	for index := range queryStatsLimit {
+		stats.add(strings.Repeat("x", index+1), 0, 0, false)
+	}
+
+	all := stats.all()
+	assert.Equal(t, queryStatsLimit, len(all))
+
+	assert.Equal(t, queryStatsSamples+10, all[0].Count)
+	assert.Equal(t, time.Duration(queryStatsSamples/2+10-1), all[0].P50)
+	assert.Equal(t, 1, all[1].Count)
+}
+
+func TestNormalizeQuery(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"SELECT * FROM user":                                  "SELECT * FROM user",
+		"  SELECT *\n\tFROM user ;  ":                         "SELECT * FROM user ;",
+		"SELECT * FROM user WHERE age > 18 AND score < -1.5":  "SELECT * FROM user WHERE age > ? AND score < ?",
+		"SELECT * FROM user WHERE name = 'a' OR name = \"b\"": "SELECT * FROM user WHERE name = ? OR name = ?",
+		"SELECT * FROM user:1 /* comment */ LIMIT 5":          "SELECT * FROM user:? LIMIT ?",
+		"SELECT * FROM `user 1` WHERE id = $id":               "SELECT * FROM `user 1` WHERE id = $id",
+		"SELECT * FROM user WHERE created > time::now() - 1h": "SELECT * FROM user WHERE created > time::now() - 1h",
+		"SELECT * FROM inf":                                   "SELECT * FROM inf",
+		"SELECT * FROM user WHERE x=1":                        "SELECT * FROM user WHERE x=?",
+		"SELECT * FROM user WHERE age>18 AND score<-1.5e3":    "SELECT * FROM user WHERE age>? AND score<?",
+		"SELECT * FROM user WHERE id IN [1,2] AND a-1 > a1":   "SELECT * FROM user WHERE id IN [?,?] AND a-? > a1",
+		"SELECT * FROM user:1, user:⟨a⟩ TIMEOUT 1h30m":        "SELECT * FROM user:?, user:? TIMEOUT 1h30m",
+		"SELECT * FROM user:⟨alice@example.com⟩, user:`bob`":  "SELECT * FROM user:?, user:?",
+		"SELECT * FROM user:tobie, user:-1, `some user`:a":    "SELECT * FROM user:?, user:?, `some user`:?",
+		"SELECT * FROM t:['London', d'2024-01-01'], t:{a: 1}": "SELECT * FROM t:?, t:?",
+		"SELECT * FROM user:1..=5, user:..5, user:a>..":       "SELECT * FROM user:?, user:?, user:?",
+		"RETURN time::now() + count(SELECT * FROM user:a)":    "RETURN time::now() + count(SELECT * FROM user:?)",
+		"RETURN { a: 1, b:[1] }":                              "RETURN { a: ?, b:? }",
+		"SELECT 'unterminated":                                invalidQuery,
+	}
+
+	for query, expected := range tests {
+		assert.Equal(t, expected, normalizeQuery(query), query)
+	}
+}
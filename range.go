@@ -0,0 +1,179 @@
+package sdbc
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+// BoundKind describes whether a bound of a range is inclusive, exclusive or absent.
+type BoundKind uint8
+
+const (
+	// BoundUnbounded denotes an open end of a range.
+	BoundUnbounded BoundKind = iota
+
+	// BoundIncluded denotes a bound that is part of the range.
+	BoundIncluded
+
+	// BoundExcluded denotes a bound that is not part of the range.
+	BoundExcluded
+)
+
+// Bound is the lower or upper bound of a Range.
+// The zero value is an unbounded (open) end.
+type Bound struct {
+	Kind  BoundKind
+	Value any
+}
+
+// Included returns a bound that is part of the range.
+func Included(val any) Bound {
+	return Bound{Kind: BoundIncluded, Value: val}
+}
+
+// Excluded returns a bound that is not part of the range.
+func Excluded(val any) Bound {
+	return Bound{Kind: BoundExcluded, Value: val}
+}
+
+// Unbounded returns an open end of a range.
+func Unbounded() Bound {
+	return Bound{Kind: BoundUnbounded}
+}
+
+func (b Bound) marshal() (cbor.RawMessage, error) {
+	switch b.Kind {
+
+	case BoundUnbounded:
+		return encodedNull, nil
+
+	case BoundIncluded:
+		return marshalTagged(cborTagBoundIncluded, b.Value)
+
+	case BoundExcluded:
+		return marshalTagged(cborTagBoundExcluded, b.Value)
+
+	default:
+		return nil, fmt.Errorf("%w: unknown bound kind %d", ErrDataInvalid, b.Kind)
+	}
+}
+
+func (b *Bound) unmarshal(data []byte) error {
+	tagNumber, content, tagged, err := splitTag(data)
+	if err != nil {
+		return err
+	}
+
+	if !tagged {
+		if !bytes.Equal(data, encodedNull) && !bytes.Equal(data, encodedUndefined) {
+			return fmt.Errorf("%w: expected tagged bound or null", ErrDataInvalid)
+		}
+
+		*b = Unbounded()
+
+		return nil
+	}
+
+	switch tagNumber {
+
+	case CBORTagNone:
+		*b = Unbounded()
+
+		return nil
+
+	case cborTagBoundIncluded:
+		b.Kind = BoundIncluded
+
+	case cborTagBoundExcluded:
+		b.Kind = BoundExcluded
+
+	default:
+		return fmt.Errorf("%w: unexpected tag %d for bound", ErrDataInvalid, tagNumber)
+	}
+
+	b.Value = nil
+
+	if err := valueUnmarshal(content, &b.Value); err != nil {
+		return fmt.Errorf("failed to unmarshal bound value: %w", err)
+	}
+
+	return nil
+}
+
+// Range is a range of values with optional lower and upper bounds,
+// e.g. 1..10, 1..=10, 1>..10, ..10 or 'a'.. in SurrealQL.
+// In SurrealDB, the lower bound of a range is included and
+// the upper bound is excluded by default.
+type Range struct {
+	Begin Bound
+	End   Bound
+}
+
+// String returns the range in the SurrealQL format.
+func (r Range) String() string {
+	var builder strings.Builder
+
+	r.format(&builder, formatValue)
+
+	return builder.String()
+}
+
+// format writes the range to the builder using the given function for the bound values.
+func (r Range) format(builder *strings.Builder, formatBound func(*strings.Builder, any)) {
+	if r.Begin.Kind != BoundUnbounded {
+		formatBound(builder, r.Begin.Value)
+	}
+
+	if r.Begin.Kind == BoundExcluded {
+		builder.WriteByte('>')
+	}
+
+	builder.WriteString("..")
+
+	if r.End.Kind == BoundIncluded {
+		builder.WriteByte('=')
+	}
+
+	if r.End.Kind != BoundUnbounded {
+		formatBound(builder, r.End.Value)
+	}
+}
+
+func (r *Range) MarshalCBOR() ([]byte, error) {
+	begin, err := r.Begin.marshal()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal range begin: %w", err)
+	}
+
+	end, err := r.End.marshal()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal range end: %w", err)
+	}
+
+	return marshalTagged(cborTagRange, []cbor.RawMessage{begin, end})
+}
+
+func (r *Range) UnmarshalCBOR(data []byte) error {
+	var bounds []cbor.RawMessage
+
+	if err := unmarshalTagged(data, cborTagRange, &bounds); err != nil {
+		return fmt.Errorf("failed to unmarshal range: %w", err)
+	}
+
+	if len(bounds) != expectedArrayLength {
+		return fmt.Errorf("%w: expected %d bounds for range, got %d", ErrDataInvalid, expectedArrayLength, len(bounds))
+	}
+
+	if err := r.Begin.unmarshal(bounds[0]); err != nil {
+		return fmt.Errorf("failed to unmarshal range begin: %w", err)
+	}
+
+	if err := r.End.unmarshal(bounds[1]); err != nil {
+		return fmt.Errorf("failed to unmarshal range end: %w", err)
+	}
+
+	return nil
+}
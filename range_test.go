@@ -0,0 +1,115 @@
+package sdbc
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+	"gotest.tools/v3/assert"
+)
+
+func TestRangeString(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		rng  Range
+		want string
+	}{
+		{rng: Range{Begin: Included(1), End: Excluded(10)}, want: "1..10"},
+		{rng: Range{Begin: Included(1), End: Included(10)}, want: "1..=10"},
+		{rng: Range{Begin: Excluded(1), End: Excluded(10)}, want: "1>..10"},
+		{rng: Range{End: Included(10)}, want: "..=10"},
+		{rng: Range{Begin: Included("a")}, want: "'a'.."},
+		{rng: Range{}, want: ".."},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.want, test.rng.String())
+	}
+}
+
+func TestRangeRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	rng := Range{Begin: Excluded("a"), End: Included([]any{"z", uint64(1)})}
+
+	data, err := cbor.Marshal(&rng)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var raw cbor.RawTag
+
+	if err := cbor.Unmarshal(data, &raw); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, uint64(cborTagRange), raw.Number)
+
+	var out Range
+
+	if err := cbor.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.DeepEqual(t, rng, out)
+
+	open := Range{}
+
+	data, err = cbor.Marshal(&open)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out = Range{Begin: Included(1)}
+
+	if err := cbor.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.DeepEqual(t, open, out)
+}
+
+func TestRangeUnmarshalNoneBound(t *testing.T) {
+	t.Parallel()
+
+	data, err := cbor.Marshal(cbor.Tag{
+		Number: cborTagRange,
+		Content: []any{
+			cbor.Tag{Number: CBORTagNone, Content: nil},
+			cbor.Tag{Number: cborTagBoundExcluded, Content: 10},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out Range
+
+	if err := cbor.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.DeepEqual(t, Range{End: Excluded(uint64(10))}, out)
+}
+
+func TestRangeUnmarshalInvalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []any{
+		cbor.Tag{Number: cborTagRange, Content: []any{nil}},
+		cbor.Tag{Number: cborTagRange, Content: []any{1, nil}},
+		cbor.Tag{Number: cborTagRange, Content: []any{cbor.Tag{Number: 1000, Content: 1}, nil}},
+	}
+
+	for _, test := range tests {
+		data, err := cbor.Marshal(test)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var out Range
+
+		assert.Check(t, errors.Is(cbor.Unmarshal(data, &out), ErrDataInvalid), "%v", test)
+	}
+}
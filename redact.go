@@ -0,0 +1,328 @@
+package sdbc
+
+import (
+	"fmt"
+	"log/slog"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+const (
+	defaultLogPayloadLimit = 1 << 10 // 1 KB
+
+	// maxRedactDepth limits the nesting of values that are
+	// redacted, which prevents endless loops for cyclic values.
+	maxRedactDepth = 32
+
+	redacted  = "[REDACTED]"
+	truncated = "[...]"
+)
+
+// sensitiveMethods are the methods whose params and
+// results are never logged, because they contain credentials.
+var sensitiveMethods = map[string]bool{
+	methodSignIn:       true,
+	methodSignUp:       true,
+	methodAuthenticate: true,
+}
+
+// logParams are the params of a request as logged. They are only
+// redacted and formatted if the log record is actually written.
+type logParams struct {
+	client *Client
+	req    request
+}
+
+func (p logParams) LogValue() slog.Value {
+	if sensitiveMethods[p.req.Method] {
+		return slog.StringValue(redacted)
+	}
+
+	params := make([]any, len(p.req.Params))
+
+	for index, param := range p.req.Params {
+		params[index] = p.client.redactValue(reflect.ValueOf(param), 0)
+	}
+
+	// The value of a variable is redacted if its name is sensitive.
+	if p.req.Method == methodLet && len(params) > 1 {
+		if name, ok := params[0].(string); ok && p.client.isSensitive(name) {
+			params[1] = redacted
+		}
+	}
+
+	return slog.StringValue(p.client.capPayload(fmt.Sprint(params)))
+}
+
+// logResult is the raw result of a response as logged. It is only
+// decoded, redacted and formatted if the log record is actually written.
+type logResult struct {
+	client *Client
+	id     string
+	result cbor.RawMessage
+}
+
+func (r logResult) LogValue() slog.Value {
+	if r.id != "" && r.client.requests.isRedacted(r.id) {
+		return slog.StringValue(redacted)
+	}
+
+	var value any
+
+	// The raw result is not logged, because it could contain anything.
+	if err := r.client.unmarshal(r.result, &value); err != nil {
+		return slog.StringValue(redactedBytes(len(r.result)))
+	}
+
+	return slog.StringValue(r.client.capPayload(fmt.Sprint(r.client.redactValue(reflect.ValueOf(value), 0))))
+}
+
+// isSensitive reports whether the value of the variable
+// or field with the given name must not be logged.
+// Variables renamed by Live are matched by their original name.
+func (c *Client) isSensitive(name string) bool {
+	_, ok := c.redactedVars[strings.ToLower(c.liveVarKey(strings.TrimPrefix(name, "$")))]
+
+	return ok
+}
+
+// liveVarKey returns the original name of a variable renamed by Live
+// (see liveVarPrefix) or the name as is, if it was not renamed.
+func (c *Client) liveVarKey(name string) string {
+	rest, ok := strings.CutPrefix(name, liveParamPrefix)
+	if !ok {
+		return name
+	}
+
+	if c.liveOwner != "" {
+		if rest, ok = strings.CutPrefix(rest, c.liveOwner+"_"); !ok {
+			return name
+		}
+	}
+
+	// the random part consists of letters only
+	if _, key, found := strings.Cut(rest, "_"); found {
+		return key
+	}
+
+	return name
+}
+
+// capPayload shortens the payload to the configured limit.
+func (c *Client) capPayload(payload string) string {
+	if c.logPayloadLimit <= 0 || len(payload) <= c.logPayloadLimit {
+		return payload
+	}
+
+	end := c.logPayloadLimit
+
+	// do not cut a multibyte character
+	for end > 0 && !utf8.RuneStart(payload[end]) {
+		end--
+	}
+
+	return payload[:end] + "... (" + strconv.Itoa(len(payload)-end) + " more bytes)"
+}
+
+// safeLogTypes are the types that are logged as they are, because
+// they cannot contain anything to redact (beyond their plain value).
+var safeLogTypes = map[reflect.Type]bool{
+	reflect.TypeFor[time.Time]():     true,
+	reflect.TypeFor[time.Duration](): true,
+	reflect.TypeFor[ID]():            true,
+	reflect.TypeFor[Table]():         true,
+	reflect.TypeFor[UUID]():          true,
+	reflect.TypeFor[DateTime]():      true,
+	reflect.TypeFor[Duration]():      true,
+	reflect.TypeFor[Decimal]():       true,
+	reflect.TypeFor[None]():          true,
+	reflect.TypeFor[File]():          true,
+	reflect.TypeFor[GeometryPoint](): true,
+}
+
+// redactValue returns a copy of the value in which the values of sensitive map
+// keys and struct fields are redacted. Only scalars and the safeLogTypes are kept
+// as they are. Scalars are logged by their plain value instead of calling their
+// String method. Structs are converted to maps and byte slices are replaced by
+// their length. Anything else (e.g. structs without exported fields, functions
+// and channels) is redacted, as its string representation could leak anything.
+func (c *Client) redactValue(val reflect.Value, depth int) any {
+	if !val.IsValid() || !val.CanInterface() {
+		return nil
+	}
+
+	if depth > maxRedactDepth {
+		return truncated
+	}
+
+	if safeLogTypes[val.Type()] {
+		return safeValue(val)
+	}
+
+	return c.redactKind(val, depth)
+}
+
+func (c *Client) redactKind(val reflect.Value, depth int) any {
+	switch val.Kind() {
+
+	case reflect.Interface, reflect.Pointer:
+		if val.IsNil() {
+			return nil
+		}
+
+		return c.redactValue(val.Elem(), depth)
+
+	case reflect.Map:
+		out := make(map[any]any, val.Len())
+
+		iter := val.MapRange()
+		for iter.Next() {
+			key := iter.Key()
+
+			// Decoded maps have keys of type any, so the key is checked by its value.
+			if key.Kind() == reflect.Interface {
+				key = key.Elem()
+			}
+
+			if key.Kind() == reflect.String && c.isSensitive(key.String()) {
+				out[redactKey(key)] = redacted
+			} else {
+				out[redactKey(key)] = c.redactValue(iter.Value(), depth+1)
+			}
+		}
+
+		return out
+
+	case reflect.Slice, reflect.Array:
+		if val.Type().Elem().Kind() == reflect.Uint8 {
+			return redactedBytes(val.Len())
+		}
+
+		out := make([]any, val.Len())
+
+		for index := range val.Len() {
+			out[index] = c.redactValue(val.Index(index), depth+1)
+		}
+
+		return out
+
+	case reflect.Struct:
+		return c.redactStruct(val, depth)
+
+	default:
+		return redactScalar(val)
+	}
+}
+
+// redactScalar returns the plain value of a scalar. Any other value is redacted.
+func redactScalar(val reflect.Value) any {
+	switch val.Kind() {
+
+	case reflect.Bool:
+		return val.Bool()
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return val.Int()
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return val.Uint()
+
+	case reflect.Float32, reflect.Float64:
+		return val.Float()
+
+	case reflect.String:
+		return val.String()
+
+	default:
+		return redacted
+	}
+}
+
+// redactKey returns the map key as logged. Keys that are neither
+// scalars nor one of the safeLogTypes are redacted.
+func redactKey(key reflect.Value) any {
+	if !key.IsValid() {
+		return nil
+	}
+
+	if safeLogTypes[key.Type()] {
+		return safeValue(key)
+	}
+
+	return redactScalar(key)
+}
+
+// safeValue returns a pointer to a copy of the value of one of the safeLogTypes,
+// because some of them implement fmt.Stringer with a pointer receiver.
+func safeValue(val reflect.Value) any {
+	ptr := reflect.New(val.Type())
+	ptr.Elem().Set(val)
+
+	return ptr.Interface()
+}
+
+// redactedBytes replaces binary data, which is not logged.
+func redactedBytes(length int) string {
+	return "[" + strconv.Itoa(length) + " bytes]"
+}
+
+func (c *Client) redactStruct(val reflect.Value, depth int) any {
+	out := map[string]any{}
+
+	for index := range val.NumField() {
+		field := val.Type().Field(index)
+		if !field.IsExported() {
+			continue
+		}
+
+		name := fieldName(field)
+		if name == "-" {
+			continue
+		}
+
+		if c.isSensitive(name) || c.hasRedactedTag(field) {
+			out[name] = redacted
+
+			continue
+		}
+
+		out[name] = c.redactValue(val.Field(index), depth+1)
+	}
+
+	// The unexported fields would be logged as well.
+	if len(out) == 0 && val.NumField() > 0 {
+		return redacted
+	}
+
+	return out
+}
+
+// hasRedactedTag reports whether the field has one of the registered tag keys.
+func (c *Client) hasRedactedTag(field reflect.StructField) bool {
+	for _, key := range c.redactedTags {
+		if _, ok := field.Tag.Lookup(key); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// fieldName returns the name of the field as encoded, taken
+// from its cbor or json tag or the name of the field itself.
+func fieldName(field reflect.StructField) string {
+	for _, key := range []string{"cbor", "json"} {
+		if tag, ok := field.Tag.Lookup(key); ok {
+			if name, _, _ := strings.Cut(tag, ","); name != "" {
+				return name
+			}
+		}
+	}
+
+	return field.Name
+}
@@ -0,0 +1,230 @@
+package sdbc
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestLogParams(t *testing.T) {
+	t.Parallel()
+
+	type credentials struct {
+		User     string `json:"user"`
+		Password string `json:"pass"`
+		Key      string `secret:"true"`
+		internal string
+	}
+
+	client := &Client{options: applyOptions([]Option{
+		WithRedactedVars("pass", "$Token"),
+		WithRedactedTag("secret"),
+	})}
+
+	logged := func(method string, params ...any) string {
+		return logParams{client: client, req: request{Method: method, Params: params}}.LogValue().String()
+	}
+
+	assert.Equal(t, redacted, logged(methodSignIn, signInParams{User: "root", Pass: "root"}))
+	assert.Equal(t, redacted, logged(methodAuthenticate, "token"))
+
+	assert.Equal(t,
+		"[SELECT * FROM user WHERE token = $token map[name:one token:[REDACTED]]]",
+		logged(methodQuery, "SELECT * FROM user WHERE token = $token", map[string]any{"name": "one", "token": "abc"}),
+	)
+
+	assert.Equal(t, "[token [REDACTED]]", logged(methodLet, "token", "abc"))
+	assert.Equal(t, "[name one]", logged(methodLet, "name", "one"))
+
+	assert.Equal(t,
+		"[user:one map[Key:[REDACTED] pass:[REDACTED] user:root]]",
+		logged(methodCreate, StringID("user", "one"), &credentials{User: "root", Password: "root", Key: "key"}),
+	)
+
+	assert.Equal(t,
+		"[[map[nested:map[TOKEN:[REDACTED]]]]]",
+		logged(methodInsert, []any{map[string]any{"nested": map[string]string{"TOKEN": "abc"}}}),
+	)
+
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, "["+now.String()+" [2 bytes]]", logged(methodSelect, now, []byte{1, 2}))
+
+	// The String method of unknown types is never called, as it could leak anything.
+	assert.Equal(t,
+		"[map[User:root pass:[REDACTED]] map[creds:map[User:root pass:[REDACTED]]] [REDACTED] abc]",
+		logged(methodCreate,
+			stringerCredentials{User: "root", Password: "root"},
+			map[string]any{"creds": &stringerCredentials{User: "root", Password: "root"}},
+			opaqueToken{token: "abc"},
+			maskedToken("abc"),
+		),
+	)
+
+	assert.Equal(t, "[[REDACTED] [REDACTED]]", logged(methodRun, func() {}, make(chan int)))
+}
+
+type stringerCredentials struct {
+	User     string
+	Password string `json:"pass"`
+}
+
+func (c stringerCredentials) String() string {
+	return c.User + ":" + c.Password
+}
+
+type opaqueToken struct {
+	token string
+}
+
+func (t opaqueToken) String() string {
+	return t.token
+}
+
+type maskedToken string
+
+func (t maskedToken) String() string {
+	return "***"
+}
+
+func TestLogResult(t *testing.T) {
+	t.Parallel()
+
+	marshal, unmarshal := newTestCodec(t, nil)
+
+	client := &Client{
+		options:   applyOptions([]Option{WithRedactedVars("pass")}),
+		marshal:   marshal,
+		unmarshal: unmarshal,
+	}
+
+	data, err := client.marshal(map[string]any{"user": "root", "pass": "root", "avatar": []byte{1, 2, 3}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "map[avatar:[3 bytes] pass:[REDACTED] user:root]",
+		logResult{client: client, result: data}.LogValue().String())
+
+	// An undecodable result is not logged as is.
+	assert.Equal(t, "[2 bytes]", logResult{client: client, result: []byte{0xff, 0xff}}.LogValue().String())
+}
+
+func TestLogRedactionLiveSessionVariables(t *testing.T) {
+	t.Parallel()
+
+	var output syncBuffer
+
+	logger := slog.New(slog.NewTextHandler(&output, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	client := newWebsocketTestClient(t, func(req request) (any, *responseError) {
+		if req.Method == methodQuery {
+			return []any{map[string]any{"status": "OK", "result": []byte("live-id")}}, nil
+		}
+
+		return nil, nil
+	}, WithLogger(logger), WithRedactedVars("password"), WithLiveSessionVariables(), WithLiveParamSweep("owner1"))
+
+	client.version = SemVer{Major: 2}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	_, err := client.Live(ctx, "SELECT * FROM user WHERE password = $password", map[string]any{
+		"password": "secret-var",
+	})
+	assert.NilError(t, err)
+
+	logs := output.String()
+
+	assert.Check(t, strings.Contains(logs, "sdbc_live_owner1_"), logs)
+	assert.Check(t, !strings.Contains(logs, "secret"), logs)
+}
+
+func TestLiveVarKey(t *testing.T) {
+	t.Parallel()
+
+	client := &Client{options: applyOptions(nil)}
+	owned := &Client{options: applyOptions([]Option{WithLiveParamSweep("owner1")})}
+
+	assert.Equal(t, "pass_word", client.liveVarKey("sdbc_live_aBcD_pass_word"))
+	assert.Equal(t, "password", owned.liveVarKey("sdbc_live_owner1_aBcD_password"))
+	assert.Equal(t, "sdbc_live_other_aBcD_password", owned.liveVarKey("sdbc_live_other_aBcD_password"))
+	assert.Equal(t, "password", client.liveVarKey("password"))
+}
+
+func TestCapPayload(t *testing.T) {
+	t.Parallel()
+
+	client := &Client{options: applyOptions([]Option{WithLogPayloadLimit(5)})}
+
+	assert.Equal(t, "short", client.capPayload("short"))
+	assert.Equal(t, "longe... (4 more bytes)", client.capPayload("longer123"))
+	assert.Equal(t, "abä... (2 more bytes)", client.capPayload("abäö"))
+
+	client = &Client{options: applyOptions([]Option{WithLogPayloadLimit(0)})}
+
+	assert.Equal(t, "longer123", client.capPayload("longer123"))
+
+	client = &Client{options: applyOptions(nil)}
+
+	capped := client.capPayload(strings.Repeat("x", defaultLogPayloadLimit+1))
+	assert.Equal(t, defaultLogPayloadLimit+len("... (1 more bytes)"), len(capped))
+}
+
+func TestLogRedaction(t *testing.T) {
+	t.Parallel()
+
+	var output syncBuffer
+
+	logger := slog.New(slog.NewTextHandler(&output, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	client := newWebsocketTestClient(t, func(req request) (any, *responseError) {
+		if req.Method == methodSignIn {
+			return "secret-token", nil
+		}
+
+		return map[string]any{"name": "one", "password": "secret-password"}, nil
+	}, WithLogger(logger), WithRedactedVars("password"))
+
+	assert.NilError(t, client.signIn(context.Background(), "root", "secret-root"))
+	assert.Equal(t, "secret-token", client.token)
+
+	_, err := client.Query(context.Background(), "SELECT * FROM user WHERE password = $password", map[string]any{
+		"password": "secret-var",
+	})
+	assert.NilError(t, err)
+
+	logs := output.String()
+
+	assert.Check(t, strings.Contains(logs, "name:one"), logs)
+	assert.Check(t, !strings.Contains(logs, "secret"), logs)
+}
+
+//
+// -- HELPER
+//
+
+type syncBuffer struct {
+	mut sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(data []byte) (int, error) {
+	b.mut.Lock()
+	defer b.mut.Unlock()
+
+	return b.buf.Write(data)
+}
+
+func (b *syncBuffer) String() string {
+	b.mut.Lock()
+	defer b.mut.Unlock()
+
+	return b.buf.String()
+}
@@ -54,7 +54,9 @@ func (c *Client) read(ctx context.Context) (*bytes.Buffer, error) {
 	}
 
 	var err error
-	defer c.checkWebsocketConn(err)
+
+	// The closure reads err when returning, not when deferring.
+	defer func() { c.checkWebsocketConn(err) }()
 
 	msgType, reader, err := c.conn.Reader(ctx)
 	if err != nil {
@@ -71,6 +73,8 @@ func (c *Client) read(ctx context.Context) (*bytes.Buffer, error) {
 		return nil, fmt.Errorf("failed to read message: %w", err)
 	}
 
+	c.metrics.AddBytesRead(buf.Len())
+
 	return buf, nil
 }
 
@@ -82,7 +86,7 @@ func (c *Client) handleMessage(buf *bytes.Buffer) {
 
 	if err := c.unmarshal(buf.Bytes(), &res); err != nil {
 		c.logger.ErrorContext(c.connCtx, "Could not unmarshal websocket message.",
-			"data", buf.String(),
+			"size", buf.Len(),
 			"error", err,
 		)
 
@@ -103,7 +107,7 @@ func (c *Client) handleMessage(buf *bytes.Buffer) {
 
 	c.logger.DebugContext(c.connCtx, "Received message.",
 		"id", res.ID,
-		"result", string(res.Result),
+		"result", logResult{client: c, id: res.ID, result: res.Result},
 	)
 
 	if res.ID == "" {
@@ -156,14 +160,43 @@ func (c *Client) handleLiveQuery(res *response) {
 		return
 	}
 
+	liveID := string(rawID.ID)
+
+	ctx := c.liveQueries.context(liveID)
+	if ctx == nil {
+		ctx = c.connCtx
+	}
+
+	end := c.startNotification(ctx, liveID, res)
+
+	deliver := func(_ context.Context, notification Notification) error {
+		return c.deliverNotification(outCh, notification)
+	}
+
+	err := c.interceptNotification(ctx, Notification{LiveID: liveID, Result: res.Result}, deliver)
+	if err != nil {
+		c.metrics.IncDroppedNotifications()
+	}
+
+	end(err)
+}
+
+// deliverNotification sends the notification to the channel of the live query.
+func (c *Client) deliverNotification(outCh chan<- []byte, notification Notification) error {
 	select {
 	case <-c.connCtx.Done():
-		c.logger.DebugContext(c.connCtx, "Context done, ignoring live query result.", logArgID, rawID.ID)
+		c.logger.DebugContext(c.connCtx, "Context done, ignoring live query result.", logArgID, notification.LiveID)
 
-	case outCh <- res.Result:
-		c.logger.DebugContext(c.connCtx, "Sent live query result to channel.", logArgID, rawID.ID)
+		return fmt.Errorf("%w: %w", ErrLiveNotificationDropped, c.connCtx.Err())
+
+	case outCh <- notification.Result:
+		c.logger.DebugContext(c.connCtx, "Sent live query result to channel.", logArgID, notification.LiveID)
+
+		return nil
 
 	case <-time.After(c.timeout):
-		c.logger.ErrorContext(c.connCtx, "Timeout while sending result to channel.", logArgID, res.ID)
+		c.logger.ErrorContext(c.connCtx, "Timeout while sending result to channel.", logArgID, notification.LiveID)
+
+		return fmt.Errorf("%w: timeout while sending result to channel", ErrLiveNotificationDropped)
 	}
 }
@@ -0,0 +1,1067 @@
+package sdbc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/go-surreal/sdbc/internal/surrealql"
+)
+
+var ErrInvalidDefinition = errors.New("invalid definition")
+
+const (
+	keywordComment     = "COMMENT"
+	keywordPermissions = "PERMISSIONS"
+	keywordFull        = "FULL"
+	keywordNone        = "NONE"
+	keywordWhere       = "WHERE"
+)
+
+// Schema describes the schema of the selected database.
+//
+// Each definition contains the DEFINE statement as reported by the
+// server, as well as its parsed parts. Expressions (e.g. assertions
+// or default values) are kept as SurrealQL.
+type Schema struct {
+	Tables    []TableDefinition
+	Analyzers []AnalyzerDefinition
+	Functions []FunctionDefinition
+	Params    []ParamDefinition
+	Accesses  []AccessDefinition
+	Users     []UserDefinition
+}
+
+// Permission is a permission clause, which is either
+// FULL, NONE or a WHERE clause (e.g. "WHERE user = $auth").
+type Permission string
+
+const (
+	PermissionFull Permission = keywordFull
+	PermissionNone Permission = keywordNone
+)
+
+// Permissions holds the permission of each kind of operation.
+// Operations not supported by the definition are left empty.
+type Permissions struct {
+	Select Permission
+	Create Permission
+	Update Permission
+	Delete Permission
+}
+
+// TableDefinition describes a table (DEFINE TABLE).
+type TableDefinition struct {
+	Name string
+
+	// Type is the type of the table (ANY, NORMAL or RELATION).
+	Type string
+
+	// In and Out are the tables a relation table connects.
+	In  []string
+	Out []string
+
+	Schemafull  bool
+	Drop        bool
+	View        string // AS SELECT ...
+	Changefeed  string // e.g. 1h or 1h INCLUDE ORIGINAL
+	Permissions Permissions
+	Comment     string
+
+	Fields  []FieldDefinition
+	Indexes []IndexDefinition
+	Events  []EventDefinition
+
+	Definition string
+}
+
+// FieldDefinition describes a field of a table (DEFINE FIELD).
+type FieldDefinition struct {
+	Name          string
+	Table         string
+	Kind          string // TYPE, e.g. option<string>
+	Flexible      bool
+	Default       string
+	DefaultAlways bool
+	Value         string
+	Assert        string
+	Readonly      bool
+	Permissions   Permissions
+	Comment       string
+	Definition    string
+}
+
+// IndexDefinition describes an index of a table (DEFINE INDEX).
+type IndexDefinition struct {
+	Name   string
+	Table  string
+	Fields []string
+
+	// Kind is the kind of index with its parameters, e.g. UNIQUE,
+	// SEARCH ANALYZER ascii BM25(1.2,0.75) or HNSW DIMENSION 4.
+	// It is empty for regular indexes.
+	Kind string
+
+	Comment    string
+	Definition string
+}
+
+// EventDefinition describes an event of a table (DEFINE EVENT).
+type EventDefinition struct {
+	Name       string
+	Table      string
+	When       string
+	Then       string
+	Comment    string
+	Definition string
+}
+
+// AnalyzerDefinition describes an analyzer (DEFINE ANALYZER).
+type AnalyzerDefinition struct {
+	Name       string
+	Function   string
+	Tokenizers []string
+	Filters    []string
+	Comment    string
+	Definition string
+}
+
+// FunctionDefinition describes a function (DEFINE FUNCTION).
+type FunctionDefinition struct {
+	Name        string // including the fn:: prefix
+	Args        []FunctionArg
+	Returns     string
+	Body        string
+	Permissions Permission
+	Comment     string
+	Definition  string
+}
+
+// FunctionArg is a single argument of a function.
+type FunctionArg struct {
+	Name string // without the leading $
+	Kind string
+}
+
+// ParamDefinition describes a database param (DEFINE PARAM).
+type ParamDefinition struct {
+	Name        string // without the leading $
+	Value       string
+	Permissions Permission
+	Comment     string
+	Definition  string
+}
+
+// AccessDefinition describes an access method (DEFINE ACCESS). For
+// SurrealDB 1.x, scopes and tokens are reported as access methods
+// of kind SCOPE and TOKEN respectively.
+type AccessDefinition struct {
+	Name       string
+	On         string // e.g. DATABASE
+	Kind       string // e.g. RECORD, JWT or BEARER
+	Comment    string
+	Definition string
+}
+
+// UserDefinition describes a database user (DEFINE USER).
+type UserDefinition struct {
+	Name       string
+	On         string // e.g. DATABASE
+	Roles      []string
+	Comment    string
+	Definition string
+}
+
+// databaseDefinitions is the result of INFO FOR DB.
+type databaseDefinitions struct {
+	Accesses  map[string]string `cbor:"accesses"`
+	Analyzers map[string]string `cbor:"analyzers"`
+	Functions map[string]string `cbor:"functions"`
+	Params    map[string]string `cbor:"params"`
+	Tables    map[string]string `cbor:"tables"`
+	Users     map[string]string `cbor:"users"`
+
+	// SurrealDB 1.x
+	Scopes map[string]string `cbor:"scopes"`
+	Tokens map[string]string `cbor:"tokens"`
+}
+
+// tableDefinitions is the result of INFO FOR TABLE.
+type tableDefinitions struct {
+	Events  map[string]string `cbor:"events"`
+	Fields  map[string]string `cbor:"fields"`
+	Indexes map[string]string `cbor:"indexes"`
+}
+
+// Schema reads the schema of the selected database. All definitions
+// are sorted by name, fields are sorted by their path.
+func (c *Client) Schema(ctx context.Context) (*Schema, error) {
+	results, err := c.queryResults(ctx, "INFO FOR DB;", nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not read database info: %w", err)
+	}
+
+	var dbDefs databaseDefinitions
+
+	if err := c.unmarshal(results[0], &dbDefs); err != nil {
+		return nil, fmt.Errorf("could not unmarshal database info: %w", err)
+	}
+
+	tableNames := slices.Sorted(maps.Keys(dbDefs.Tables))
+	tableDefs := make([]tableDefinitions, len(tableNames))
+
+	if len(tableNames) > 0 {
+		var query strings.Builder
+
+		for _, name := range tableNames {
+			query.WriteString("INFO FOR TABLE " + Table(name).String() + ";\n")
+		}
+
+		results, err := c.queryResults(ctx, query.String(), nil)
+		if err != nil {
+			return nil, fmt.Errorf("could not read table info: %w", err)
+		}
+
+		if len(results) != len(tableNames) {
+			return nil, fmt.Errorf("%w: expected %d table infos, got %d", ErrEmptyResponse, len(tableNames), len(results))
+		}
+
+		for index, result := range results {
+			if err := c.unmarshal(result, &tableDefs[index]); err != nil {
+				return nil, fmt.Errorf("could not unmarshal table info: %w", err)
+			}
+		}
+	}
+
+	return parseSchema(dbDefs, tableNames, tableDefs)
+}
+
+func parseSchema(dbDefs databaseDefinitions, tableNames []string, tableDefs []tableDefinitions) (*Schema, error) {
+	var (
+		schema Schema
+		err    error
+	)
+
+	for index, name := range tableNames {
+		table, err := parseTable(dbDefs.Tables[name])
+		if err != nil {
+			return nil, err
+		}
+
+		if table.Fields, err = parseAll(tableDefs[index].Fields, parseField); err != nil {
+			return nil, err
+		}
+
+		if table.Indexes, err = parseAll(tableDefs[index].Indexes, parseIndex); err != nil {
+			return nil, err
+		}
+
+		if table.Events, err = parseAll(tableDefs[index].Events, parseEvent); err != nil {
+			return nil, err
+		}
+
+		schema.Tables = append(schema.Tables, table)
+	}
+
+	if schema.Analyzers, err = parseAll(dbDefs.Analyzers, parseAnalyzer); err != nil {
+		return nil, err
+	}
+
+	if schema.Functions, err = parseAll(dbDefs.Functions, parseFunction); err != nil {
+		return nil, err
+	}
+
+	if schema.Params, err = parseAll(dbDefs.Params, parseParam); err != nil {
+		return nil, err
+	}
+
+	accesses := make(map[string]string, len(dbDefs.Accesses)+len(dbDefs.Scopes)+len(dbDefs.Tokens))
+
+	for _, defs := range []map[string]string{dbDefs.Accesses, dbDefs.Scopes, dbDefs.Tokens} {
+		for name, def := range defs {
+			accesses[name] = def
+		}
+	}
+
+	if schema.Accesses, err = parseAll(accesses, parseAccess); err != nil {
+		return nil, err
+	}
+
+	if schema.Users, err = parseAll(dbDefs.Users, parseUser); err != nil {
+		return nil, err
+	}
+
+	return &schema, nil
+}
+
+// parseAll parses all definitions of the map, sorted by their key.
+func parseAll[T any](defs map[string]string, parse func(def string) (T, error)) ([]T, error) {
+	if len(defs) == 0 {
+		return nil, nil
+	}
+
+	out := make([]T, 0, len(defs))
+
+	for _, key := range slices.Sorted(maps.Keys(defs)) {
+		parsed, err := parse(defs[key])
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(out, parsed)
+	}
+
+	return out, nil
+}
+
+//
+// -- DEFINITIONS
+//
+
+func parseTable(def string) (TableDefinition, error) {
+	words, err := definitionWords(def, "TABLE")
+	if err != nil {
+		return TableDefinition{}, err
+	}
+
+	table := TableDefinition{Name: unescapeIdent(words[0]), Definition: def}
+
+	clauses := splitClauses(words[1:], clauseKeywords{
+		"TYPE": nil, "RELATION": nil, "IN": nil, "FROM": nil, "OUT": nil, "TO": nil, "ENFORCED": nil,
+		"SCHEMAFULL": nil, "SCHEMALESS": nil, "DROP": nil, "CHANGEFEED": nil, keywordComment: nil,
+		"AS":               {"CHANGEFEED", keywordComment, keywordPermissions},
+		keywordPermissions: {keywordComment},
+	})
+
+	for _, clause := range clauses {
+		switch clause.keyword {
+
+		case "TYPE":
+			table.Type = clause.text()
+
+		case "RELATION":
+			table.Type = "RELATION"
+
+		case "IN", "FROM":
+			table.In = splitAlternatives(clause.text())
+
+		case "OUT", "TO":
+			table.Out = splitAlternatives(clause.text())
+
+		case "SCHEMAFULL":
+			table.Schemafull = true
+
+		case "DROP":
+			table.Drop = true
+
+		case "AS":
+			table.View = clause.text()
+
+		case "CHANGEFEED":
+			table.Changefeed = clause.text()
+
+		case keywordPermissions:
+			table.Permissions = parsePermissions(clause.words)
+
+		case keywordComment:
+			table.Comment = unquote(clause.text())
+		}
+	}
+
+	return table, nil
+}
+
+func parseField(def string) (FieldDefinition, error) {
+	words, err := definitionWords(def, "FIELD")
+	if err != nil {
+		return FieldDefinition{}, err
+	}
+
+	field := FieldDefinition{Name: unescapeFieldPath(words[0]), Definition: def}
+
+	clauses := splitClauses(words[1:], clauseKeywords{
+		"ON": nil, "FLEXIBLE": nil, "FLEXI": nil, "FLEX": nil, "TYPE": nil, "DEFAULT": nil,
+		"READONLY": nil, "VALUE": nil, "ASSERT": nil, keywordComment: nil,
+		"REFERENCE":        {"DEFAULT", "READONLY", "VALUE", "ASSERT", keywordPermissions, keywordComment},
+		keywordPermissions: {keywordComment},
+	})
+
+	for _, clause := range clauses {
+		switch clause.keyword {
+
+		case "ON":
+			field.Table = unescapeIdent(strings.TrimPrefix(clause.text(), "TABLE "))
+
+		case "FLEXIBLE", "FLEXI", "FLEX":
+			field.Flexible = true
+
+		case "TYPE":
+			field.Kind = clause.text()
+
+		case "DEFAULT":
+			if len(clause.words) > 0 && strings.EqualFold(clause.words[0], "ALWAYS") {
+				field.DefaultAlways = true
+				field.Default = strings.Join(clause.words[1:], " ")
+			} else {
+				field.Default = clause.text()
+			}
+
+		case "READONLY":
+			field.Readonly = true
+
+		case "VALUE":
+			field.Value = clause.text()
+
+		case "ASSERT":
+			field.Assert = clause.text()
+
+		case keywordPermissions:
+			field.Permissions = parsePermissions(clause.words)
+
+		case keywordComment:
+			field.Comment = unquote(clause.text())
+		}
+	}
+
+	return field, nil
+}
+
+func parseIndex(def string) (IndexDefinition, error) {
+	words, err := definitionWords(def, "INDEX")
+	if err != nil {
+		return IndexDefinition{}, err
+	}
+
+	index := IndexDefinition{Name: unescapeIdent(words[0]), Definition: def}
+
+	clauses := splitClauses(words[1:], clauseKeywords{
+		"ON": nil, "FIELDS": nil, "COLUMNS": nil, "UNIQUE": nil, "CONCURRENTLY": nil, keywordComment: nil,
+		"SEARCH": {keywordComment, "CONCURRENTLY"},
+		"MTREE":  {keywordComment, "CONCURRENTLY"},
+		"HNSW":   {keywordComment, "CONCURRENTLY"},
+	})
+
+	for _, clause := range clauses {
+		switch clause.keyword {
+
+		case "ON":
+			index.Table = unescapeIdent(strings.TrimPrefix(clause.text(), "TABLE "))
+
+		case "FIELDS", "COLUMNS":
+			for _, field := range splitList(clause.text()) {
+				index.Fields = append(index.Fields, unescapeFieldPath(field))
+			}
+
+		case "UNIQUE", "SEARCH", "MTREE", "HNSW":
+			index.Kind = strings.TrimSpace(clause.keyword + " " + clause.text())
+
+		case keywordComment:
+			index.Comment = unquote(clause.text())
+		}
+	}
+
+	return index, nil
+}
+
+func parseEvent(def string) (EventDefinition, error) {
+	words, err := definitionWords(def, "EVENT")
+	if err != nil {
+		return EventDefinition{}, err
+	}
+
+	event := EventDefinition{Name: unescapeIdent(words[0]), Definition: def}
+
+	clauses := splitClauses(words[1:], clauseKeywords{
+		"ON":           nil,
+		"WHEN":         {"THEN"},
+		"THEN":         {keywordComment},
+		keywordComment: nil,
+	})
+
+	for _, clause := range clauses {
+		switch clause.keyword {
+
+		case "ON":
+			event.Table = unescapeIdent(strings.TrimPrefix(clause.text(), "TABLE "))
+
+		case "WHEN":
+			event.When = clause.text()
+
+		case "THEN":
+			event.Then = clause.text()
+
+		case keywordComment:
+			event.Comment = unquote(clause.text())
+		}
+	}
+
+	return event, nil
+}
+
+func parseAnalyzer(def string) (AnalyzerDefinition, error) {
+	words, err := definitionWords(def, "ANALYZER")
+	if err != nil {
+		return AnalyzerDefinition{}, err
+	}
+
+	analyzer := AnalyzerDefinition{Name: unescapeIdent(words[0]), Definition: def}
+
+	clauses := splitClauses(words[1:], clauseKeywords{
+		"FUNCTION": nil, "TOKENIZERS": nil, "FILTERS": nil, keywordComment: nil,
+	})
+
+	for _, clause := range clauses {
+		switch clause.keyword {
+
+		case "FUNCTION":
+			analyzer.Function = clause.text()
+
+		case "TOKENIZERS":
+			analyzer.Tokenizers = splitList(clause.text())
+
+		case "FILTERS":
+			analyzer.Filters = splitList(clause.text())
+
+		case keywordComment:
+			analyzer.Comment = unquote(clause.text())
+		}
+	}
+
+	return analyzer, nil
+}
+
+func parseFunction(def string) (FunctionDefinition, error) {
+	words, err := definitionWords(def, "FUNCTION")
+	if err != nil {
+		return FunctionDefinition{}, err
+	}
+
+	function := FunctionDefinition{Definition: def}
+
+	// The signature is a single word, e.g. fn::greet($name: string).
+	name, args, ok := strings.Cut(words[0], "(")
+	if !ok || !strings.HasSuffix(args, ")") {
+		return FunctionDefinition{}, fmt.Errorf("%w: %s", ErrInvalidDefinition, def)
+	}
+
+	function.Name = name
+
+	for _, arg := range splitList(strings.TrimSuffix(args, ")")) {
+		argName, kind, _ := strings.Cut(arg, ":")
+
+		function.Args = append(function.Args, FunctionArg{
+			Name: strings.TrimPrefix(strings.TrimSpace(argName), "$"),
+			Kind: strings.TrimSpace(kind),
+		})
+	}
+
+	clauses := splitClauses(words[1:], clauseKeywords{
+		"->":               nil,
+		keywordComment:     nil,
+		keywordPermissions: {keywordComment},
+	})
+
+	for _, clause := range clauses {
+		switch clause.keyword {
+
+		case "", "->":
+			// The body is the block following the signature or the return type.
+			for index, word := range clause.words {
+				if strings.HasPrefix(word, "{") {
+					function.Returns = strings.Join(clause.words[:index], " ")
+					function.Body = strings.Join(clause.words[index:], " ")
+
+					break
+				}
+			}
+
+		case keywordPermissions:
+			function.Permissions = parsePermission(clause.words)
+
+		case keywordComment:
+			function.Comment = unquote(clause.text())
+		}
+	}
+
+	return function, nil
+}
+
+func parseParam(def string) (ParamDefinition, error) {
+	words, err := definitionWords(def, "PARAM")
+	if err != nil {
+		return ParamDefinition{}, err
+	}
+
+	param := ParamDefinition{Name: strings.TrimPrefix(words[0], "$"), Definition: def}
+
+	clauses := splitClauses(words[1:], clauseKeywords{
+		"VALUE":            {keywordComment, keywordPermissions},
+		keywordComment:     nil,
+		keywordPermissions: {keywordComment},
+	})
+
+	for _, clause := range clauses {
+		switch clause.keyword {
+
+		case "VALUE":
+			param.Value = clause.text()
+
+		case keywordPermissions:
+			param.Permissions = parsePermission(clause.words)
+
+		case keywordComment:
+			param.Comment = unquote(clause.text())
+		}
+	}
+
+	return param, nil
+}
+
+func parseAccess(def string) (AccessDefinition, error) {
+	words, err := definitionWords(def, "ACCESS", "SCOPE", "TOKEN")
+	if err != nil {
+		return AccessDefinition{}, err
+	}
+
+	access := AccessDefinition{
+		Name:       unescapeIdent(words[0]),
+		On:         "DATABASE",
+		Kind:       wordAfter(words, "TYPE"),
+		Comment:    unquote(wordAfter(words, keywordComment)),
+		Definition: def,
+	}
+
+	if on := wordAfter(words, "ON"); on != "" {
+		access.On = strings.ToUpper(on)
+	}
+
+	// SurrealDB 1.x
+	switch kind := strings.ToUpper(strings.Fields(def)[1]); kind {
+
+	case "SCOPE", "TOKEN":
+		access.Kind = kind
+	}
+
+	return access, nil
+}
+
+func parseUser(def string) (UserDefinition, error) {
+	words, err := definitionWords(def, "USER")
+	if err != nil {
+		return UserDefinition{}, err
+	}
+
+	user := UserDefinition{Name: unescapeIdent(words[0]), Definition: def}
+
+	clauses := splitClauses(words[1:], clauseKeywords{
+		"ON": nil, "PASSWORD": nil, "PASSHASH": nil, "ROLES": nil, "DURATION": nil, keywordComment: nil,
+	})
+
+	for _, clause := range clauses {
+		switch clause.keyword {
+
+		case "ON":
+			user.On = strings.ToUpper(clause.text())
+
+		case "ROLES":
+			user.Roles = splitList(clause.text())
+
+		case keywordComment:
+			user.Comment = unquote(clause.text())
+		}
+	}
+
+	return user, nil
+}
+
+//
+// -- PERMISSIONS
+//
+
+// parsePermissions parses the words following the PERMISSIONS keyword,
+// e.g. NONE, FULL or FOR select, create FULL, FOR update WHERE x = 1.
+func parsePermissions(words []string) Permissions {
+	var perms Permissions
+
+	if !strings.EqualFold(firstWord(words), "FOR") {
+		perm := parsePermission(words)
+
+		return Permissions{Select: perm, Create: perm, Update: perm, Delete: perm}
+	}
+
+	for _, clause := range splitClauses(words, clauseKeywords{"FOR": {"FOR"}}) {
+		var (
+			kinds []string
+			rest  []string
+		)
+
+		// The kinds of operations are followed by the permission.
+		for index, word := range clause.words {
+			switch strings.ToUpper(strings.TrimSuffix(word, ",")) {
+
+			case keywordFull, keywordNone, keywordWhere:
+				rest = clause.words[index:]
+
+			default:
+				kinds = append(kinds, strings.Split(strings.Trim(word, ","), ",")...)
+
+				continue
+			}
+
+			break
+		}
+
+		perm := parsePermission(rest)
+
+		for _, kind := range kinds {
+			switch strings.ToLower(strings.TrimSpace(kind)) {
+
+			case "select":
+				perms.Select = perm
+
+			case "create":
+				perms.Create = perm
+
+			case "update":
+				perms.Update = perm
+
+			case "delete":
+				perms.Delete = perm
+			}
+		}
+	}
+
+	return perms
+}
+
+// parsePermission parses a single permission (FULL, NONE or WHERE ...).
+func parsePermission(words []string) Permission {
+	text := strings.TrimSuffix(strings.Join(words, " "), ",")
+
+	switch strings.ToUpper(text) {
+
+	case keywordFull:
+		return PermissionFull
+
+	case keywordNone:
+		return PermissionNone
+
+	default:
+		return Permission(text)
+	}
+}
+
+//
+// -- HELPER
+//
+
+// definitionWords splits the DEFINE statement into words and returns those
+// following the expected kind of definition and the optional OVERWRITE or
+// IF NOT EXISTS clauses, starting with the name of the definition.
+func definitionWords(def string, kinds ...string) ([]string, error) {
+	words, err := splitWords(def)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidDefinition, err)
+	}
+
+	if len(words) < 3 || !strings.EqualFold(words[0], "DEFINE") || !slices.ContainsFunc(kinds, func(kind string) bool {
+		return strings.EqualFold(words[1], kind)
+	}) {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidDefinition, def)
+	}
+
+	words = words[2:]
+
+	switch {
+
+	case strings.EqualFold(words[0], "OVERWRITE"):
+		words = words[1:]
+
+	case len(words) > 3 && strings.EqualFold(strings.Join(words[:3], " "), "IF NOT EXISTS"):
+		words = words[3:]
+	}
+
+	if len(words) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidDefinition, def)
+	}
+
+	return words, nil
+}
+
+// splitWords splits the statement at whitespace outside of literals and
+// brackets, so that e.g. a block ({ ... }) or a call (fn(a, b)) is a single word.
+// Comments are dropped, a trailing semicolon is removed.
+func splitWords(stmt string) ([]string, error) {
+	tokens, err := lex(strings.TrimSpace(stmt))
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		words   []string
+		current strings.Builder
+		depth   int
+	)
+
+	flush := func() {
+		if current.Len() > 0 {
+			words = append(words, current.String())
+			current.Reset()
+		}
+	}
+
+	input := strings.TrimSpace(stmt)
+
+	for _, tok := range tokens {
+		text := input[tok.Start:tok.End]
+
+		switch tok.Kind {
+
+		case surrealql.TokenSpace, surrealql.TokenComment:
+			if depth == 0 {
+				flush()
+
+				continue
+			}
+
+		case surrealql.TokenSemicolon:
+			if depth == 0 {
+				flush()
+
+				continue
+			}
+
+		case surrealql.TokenOpen:
+			depth++
+
+		case surrealql.TokenClose:
+			depth = max(depth-1, 0)
+
+		default:
+		}
+
+		current.WriteString(text)
+	}
+
+	flush()
+
+	return words, nil
+}
+
+// clauseKeywords maps the keywords starting a clause to the keywords ending it.
+// If nil, the clause ends with any of the keywords.
+type clauseKeywords map[string][]string
+
+type clause struct {
+	keyword string
+	words   []string
+}
+
+func (c clause) text() string {
+	return strings.Join(c.words, " ")
+}
+
+// splitClauses splits the words into clauses, each starting with one of the
+// keywords (case-insensitive). Words before the first keyword are returned
+// as a clause with an empty keyword.
+func splitClauses(words []string, keywords clauseKeywords) []clause {
+	var (
+		clauses []clause
+		current = clause{}
+		stops   []string
+	)
+
+	for _, word := range words {
+		upper := strings.ToUpper(word)
+
+		ends, isKeyword := keywords[upper]
+
+		if isKeyword && (current.keyword == "" || stops == nil || slices.Contains(stops, upper)) {
+			if current.keyword != "" || len(current.words) > 0 {
+				clauses = append(clauses, current)
+			}
+
+			current, stops = clause{keyword: upper}, ends
+
+			continue
+		}
+
+		current.words = append(current.words, word)
+	}
+
+	if current.keyword != "" || len(current.words) > 0 {
+		clauses = append(clauses, current)
+	}
+
+	return clauses
+}
+
+// splitList splits the text at commas outside of literals and brackets.
+func splitList(text string) []string {
+	tokens, err := lex(text)
+	if err != nil {
+		return []string{strings.TrimSpace(text)}
+	}
+
+	var (
+		items []string
+		depth int
+		start int
+	)
+
+	for _, tok := range tokens {
+		switch tok.Kind {
+
+		case surrealql.TokenOpen:
+			depth++
+
+		case surrealql.TokenClose:
+			depth = max(depth-1, 0)
+
+		case surrealql.TokenOther:
+			if depth > 0 {
+				continue
+			}
+
+			for index := tok.Start; index < tok.End; index++ {
+				if text[index] == ',' {
+					items = append(items, text[start:index])
+					start = index + 1
+				}
+			}
+
+		default:
+		}
+	}
+
+	items = append(items, text[start:])
+
+	out := make([]string, 0, len(items))
+
+	for _, item := range items {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+
+	return out
+}
+
+// splitAlternatives splits tables separated by | or OR, e.g. "user | admin".
+func splitAlternatives(text string) []string {
+	var out []string
+
+	for _, part := range strings.FieldsFunc(text, func(char rune) bool { return char == '|' || char == ' ' }) {
+		if !strings.EqualFold(part, "OR") {
+			out = append(out, unescapeIdent(part))
+		}
+	}
+
+	return out
+}
+
+// wordAfter returns the word following the given keyword (case-insensitive).
+func wordAfter(words []string, keyword string) string {
+	for index := range len(words) - 1 {
+		if strings.EqualFold(words[index], keyword) {
+			return words[index+1]
+		}
+	}
+
+	return ""
+}
+
+func firstWord(words []string) string {
+	if len(words) == 0 {
+		return ""
+	}
+
+	return words[0]
+}
+
+// unquote returns the content of a string literal. If the
+// text is no valid string literal, it is returned as is.
+func unquote(text string) string {
+	parser := &idParser{input: text}
+
+	str, err := parser.parseString()
+	if err != nil || !parser.done() {
+		return text
+	}
+
+	return str
+}
+
+// unescapeIdent returns the identifier without escaping.
+// If the identifier is not validly escaped, it is returned as is.
+func unescapeIdent(ident string) string {
+	var (
+		str string
+		err error
+	)
+
+	parser := &idParser{input: ident}
+
+	switch {
+
+	case strings.HasPrefix(ident, "`"):
+		str, err = parser.parseEscaped('`', '`')
+
+	case strings.HasPrefix(ident, "⟨"):
+		str, err = parser.parseEscaped('⟨', '⟩')
+
+	default:
+		return ident
+	}
+
+	if err != nil || !parser.done() {
+		return ident
+	}
+
+	return str
+}
+
+// unescapeFieldPath returns the field path with each part unescaped.
+func unescapeFieldPath(path string) string {
+	parts, err := splitWordsAt(path, '.')
+	if err != nil {
+		return path
+	}
+
+	for index, part := range parts {
+		parts[index] = unescapeIdent(part)
+	}
+
+	return strings.Join(parts, ".")
+}
+
+// splitWordsAt splits the text at the given separator outside of escaped identifiers.
+func splitWordsAt(text string, sep byte) ([]string, error) {
+	tokens, err := lex(text)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		parts []string
+		start int
+	)
+
+	for _, tok := range tokens {
+		if tok.Kind != surrealql.TokenOther {
+			continue
+		}
+
+		for index := tok.Start; index < tok.End; index++ {
+			if text[index] == sep {
+				parts = append(parts, text[start:index])
+				start = index + 1
+			}
+		}
+	}
+
+	return append(parts, text[start:]), nil
+}
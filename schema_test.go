@@ -0,0 +1,365 @@
+package sdbc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestSchema(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	client, cleanup := prepareSurreal(ctx, t)
+	defer cleanup()
+
+	_, err := client.Query(ctx, `
+		DEFINE TABLE user SCHEMAFULL COMMENT 'all users' PERMISSIONS FOR select FULL, FOR create, update, delete NONE;
+		DEFINE FIELD email ON user TYPE string ASSERT string::is::email($value);
+		DEFINE FIELD tags ON user TYPE option<array<string>> DEFAULT [];
+		DEFINE INDEX email ON user FIELDS email UNIQUE;
+		DEFINE EVENT created ON user WHEN $event = 'CREATE' THEN (CREATE log SET user = $after.id);
+		DEFINE TABLE likes TYPE RELATION IN user OUT user;
+		DEFINE ANALYZER simple TOKENIZERS blank, class FILTERS lowercase;
+		DEFINE FUNCTION fn::greet($name: string) { RETURN 'Hello ' + $name; };
+		DEFINE PARAM $limit VALUE 10;
+	`, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schema, err := client.Schema(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 2, len(schema.Tables))
+
+	likes, user := schema.Tables[0], schema.Tables[1]
+
+	assert.Equal(t, "likes", likes.Name)
+	assert.Equal(t, "RELATION", likes.Type)
+	assert.DeepEqual(t, []string{"user"}, likes.In)
+	assert.DeepEqual(t, []string{"user"}, likes.Out)
+
+	assert.Equal(t, "user", user.Name)
+	assert.Check(t, user.Schemafull)
+	assert.Equal(t, "all users", user.Comment)
+	assert.Equal(t, PermissionFull, user.Permissions.Select)
+	assert.Equal(t, PermissionNone, user.Permissions.Delete)
+
+	assert.Equal(t, 2, len(user.Fields))
+	assert.Equal(t, "email", user.Fields[0].Name)
+	assert.Equal(t, "string", user.Fields[0].Kind)
+	assert.Equal(t, "string::is::email($value)", user.Fields[0].Assert)
+	assert.Equal(t, "option<array<string>>", user.Fields[1].Kind)
+	assert.Equal(t, "[]", user.Fields[1].Default)
+
+	assert.Equal(t, 1, len(user.Indexes))
+	assert.DeepEqual(t, []string{"email"}, user.Indexes[0].Fields)
+	assert.Equal(t, "UNIQUE", user.Indexes[0].Kind)
+
+	assert.Equal(t, 1, len(user.Events))
+	assert.Equal(t, "created", user.Events[0].Name)
+
+	assert.Equal(t, 1, len(schema.Analyzers))
+	assert.DeepEqual(t, []string{"blank", "class"}, schema.Analyzers[0].Tokenizers)
+
+	assert.Equal(t, 1, len(schema.Functions))
+	assert.Equal(t, "fn::greet", schema.Functions[0].Name)
+	assert.DeepEqual(t, []FunctionArg{{Name: "name", Kind: "string"}}, schema.Functions[0].Args)
+
+	assert.Equal(t, 1, len(schema.Params))
+	assert.Equal(t, "limit", schema.Params[0].Name)
+	assert.Equal(t, "10", schema.Params[0].Value)
+}
+
+func TestParseTable(t *testing.T) {
+	t.Parallel()
+
+	table, err := parseTable("DEFINE TABLE user TYPE NORMAL SCHEMAFULL CHANGEFEED 1h INCLUDE ORIGINAL " +
+		"COMMENT 'all \\'users\\'' PERMISSIONS FOR select FULL, FOR create, update WHERE id = $auth.id, FOR delete NONE")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.DeepEqual(t, TableDefinition{
+		Name:       "user",
+		Type:       "NORMAL",
+		Schemafull: true,
+		Changefeed: "1h INCLUDE ORIGINAL",
+		Comment:    "all 'users'",
+		Permissions: Permissions{
+			Select: PermissionFull,
+			Create: "WHERE id = $auth.id",
+			Update: "WHERE id = $auth.id",
+			Delete: PermissionNone,
+		},
+		Definition: table.Definition,
+	}, table)
+
+	table, err = parseTable("DEFINE TABLE ⟨user stats⟩ TYPE ANY SCHEMALESS DROP " +
+		"AS SELECT count() AS total FROM user GROUP ALL PERMISSIONS NONE")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "user stats", table.Name)
+	assert.Equal(t, "ANY", table.Type)
+	assert.Check(t, !table.Schemafull)
+	assert.Check(t, table.Drop)
+	assert.Equal(t, "SELECT count() AS total FROM user GROUP ALL", table.View)
+	assert.Equal(t, PermissionNone, table.Permissions.Update)
+
+	table, err = parseTable("DEFINE TABLE OVERWRITE likes TYPE RELATION IN user | admin OUT post ENFORCED SCHEMALESS")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "likes", table.Name)
+	assert.Equal(t, "RELATION", table.Type)
+	assert.DeepEqual(t, []string{"user", "admin"}, table.In)
+	assert.DeepEqual(t, []string{"post"}, table.Out)
+}
+
+func TestParseField(t *testing.T) {
+	t.Parallel()
+
+	field, err := parseField("DEFINE FIELD address.`zip code` ON user FLEXIBLE TYPE option<object> " +
+		"DEFAULT ALWAYS {} READONLY VALUE $value OR {} ASSERT $value != NONE AND $value.country IN ['DE', 'AT'] " +
+		"COMMENT 'the address' PERMISSIONS FOR select, update FULL, FOR create NONE")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.DeepEqual(t, FieldDefinition{
+		Name:          "address.zip code",
+		Table:         "user",
+		Kind:          "option<object>",
+		Flexible:      true,
+		Default:       "{}",
+		DefaultAlways: true,
+		Value:         "$value OR {}",
+		Assert:        "$value != NONE AND $value.country IN ['DE', 'AT']",
+		Readonly:      true,
+		Comment:       "the address",
+		Permissions: Permissions{
+			Select: PermissionFull,
+			Create: PermissionNone,
+			Update: PermissionFull,
+		},
+		Definition: field.Definition,
+	}, field)
+
+	field, err = parseField("DEFINE FIELD IF NOT EXISTS name ON TABLE user TYPE string DEFAULT 'a; b' PERMISSIONS FULL")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "name", field.Name)
+	assert.Equal(t, "user", field.Table)
+	assert.Equal(t, "'a; b'", field.Default)
+	assert.Equal(t, PermissionFull, field.Permissions.Select)
+	assert.Equal(t, PermissionFull, field.Permissions.Delete)
+}
+
+func TestParseIndex(t *testing.T) {
+	t.Parallel()
+
+	index, err := parseIndex("DEFINE INDEX search ON post FIELDS title, `sub title` " +
+		"SEARCH ANALYZER simple BM25(1.2,0.75) HIGHLIGHTS COMMENT 'full text'")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.DeepEqual(t, IndexDefinition{
+		Name:       "search",
+		Table:      "post",
+		Fields:     []string{"title", "sub title"},
+		Kind:       "SEARCH ANALYZER simple BM25(1.2,0.75) HIGHLIGHTS",
+		Comment:    "full text",
+		Definition: index.Definition,
+	}, index)
+
+	index, err = parseIndex("DEFINE INDEX idx ON TABLE post COLUMNS author")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "post", index.Table)
+	assert.DeepEqual(t, []string{"author"}, index.Fields)
+	assert.Equal(t, "", index.Kind)
+}
+
+func TestParseEvent(t *testing.T) {
+	t.Parallel()
+
+	event, err := parseEvent("DEFINE EVENT changed ON user WHEN $before.email != $after.email " +
+		"THEN { CREATE log SET user = $value.id; RETURN NONE; } COMMENT 'log changes'")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.DeepEqual(t, EventDefinition{
+		Name:       "changed",
+		Table:      "user",
+		When:       "$before.email != $after.email",
+		Then:       "{ CREATE log SET user = $value.id; RETURN NONE; }",
+		Comment:    "log changes",
+		Definition: event.Definition,
+	}, event)
+}
+
+func TestParseAnalyzer(t *testing.T) {
+	t.Parallel()
+
+	analyzer, err := parseAnalyzer("DEFINE ANALYZER autocomplete FUNCTION fn::strip " +
+		"TOKENIZERS blank,class FILTERS lowercase,edgengram(2,10)")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.DeepEqual(t, AnalyzerDefinition{
+		Name:       "autocomplete",
+		Function:   "fn::strip",
+		Tokenizers: []string{"blank", "class"},
+		Filters:    []string{"lowercase", "edgengram(2,10)"},
+		Definition: analyzer.Definition,
+	}, analyzer)
+}
+
+func TestParseFunction(t *testing.T) {
+	t.Parallel()
+
+	function, err := parseFunction("DEFINE FUNCTION fn::greet($name: string, $times: option<int>) -> string " +
+		"{ RETURN 'Hello, ' + $name + '!'; } COMMENT 'greets' PERMISSIONS WHERE $auth.admin = true")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.DeepEqual(t, FunctionDefinition{
+		Name: "fn::greet",
+		Args: []FunctionArg{
+			{Name: "name", Kind: "string"},
+			{Name: "times", Kind: "option<int>"},
+		},
+		Returns:     "string",
+		Body:        "{ RETURN 'Hello, ' + $name + '!'; }",
+		Permissions: "WHERE $auth.admin = true",
+		Comment:     "greets",
+		Definition:  function.Definition,
+	}, function)
+
+	function, err = parseFunction("DEFINE FUNCTION fn::now() { RETURN time::now(); } PERMISSIONS FULL")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "fn::now", function.Name)
+	assert.Equal(t, 0, len(function.Args))
+	assert.Equal(t, "", function.Returns)
+	assert.Equal(t, "{ RETURN time::now(); }", function.Body)
+	assert.Equal(t, PermissionFull, function.Permissions)
+}
+
+func TestParseParam(t *testing.T) {
+	t.Parallel()
+
+	param, err := parseParam("DEFINE PARAM $endpoint VALUE 'https://example.com' COMMENT 'api' PERMISSIONS NONE")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.DeepEqual(t, ParamDefinition{
+		Name:        "endpoint",
+		Value:       "'https://example.com'",
+		Permissions: PermissionNone,
+		Comment:     "api",
+		Definition:  param.Definition,
+	}, param)
+}
+
+func TestParseAccessAndUser(t *testing.T) {
+	t.Parallel()
+
+	access, err := parseAccess("DEFINE ACCESS account ON DATABASE TYPE RECORD " +
+		"SIGNUP (CREATE user SET email = $email) SIGNIN (SELECT * FROM user WHERE email = $email) " +
+		"WITH JWT ALGORITHM HS512 KEY '[REDACTED]' DURATION FOR TOKEN 1h, FOR SESSION 1d")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "account", access.Name)
+	assert.Equal(t, "DATABASE", access.On)
+	assert.Equal(t, "RECORD", access.Kind)
+
+	access, err = parseAccess("DEFINE SCOPE account SESSION 1d SIGNIN (SELECT * FROM user)")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "SCOPE", access.Kind)
+	assert.Equal(t, "DATABASE", access.On)
+
+	user, err := parseUser("DEFINE USER admin ON DATABASE PASSHASH '$argon2id$...' ROLES OWNER, EDITOR " +
+		"DURATION FOR TOKEN 1h COMMENT 'the admin'")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.DeepEqual(t, UserDefinition{
+		Name:       "admin",
+		On:         "DATABASE",
+		Roles:      []string{"OWNER", "EDITOR"},
+		Comment:    "the admin",
+		Definition: user.Definition,
+	}, user)
+}
+
+func TestParseDefinitionErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []func() error{
+		func() error { _, err := parseTable("DEFINE FIELD x ON y"); return err },
+		func() error { _, err := parseTable("DEFINE TABLE"); return err },
+		func() error { _, err := parseTable("DEFINE TABLE x COMMENT 'unterminated"); return err },
+		func() error { _, err := parseFunction("DEFINE FUNCTION fn::x { RETURN 1; }"); return err },
+	}
+
+	for _, test := range tests {
+		assert.Check(t, errors.Is(test(), ErrInvalidDefinition))
+	}
+}
+
+func TestParseSchemaLegacy(t *testing.T) {
+	t.Parallel()
+
+	schema, err := parseSchema(
+		databaseDefinitions{
+			Tables: map[string]string{"user": "DEFINE TABLE user SCHEMALESS PERMISSIONS NONE"},
+			Scopes: map[string]string{"account": "DEFINE SCOPE account SESSION 1d"},
+			Tokens: map[string]string{"jwt": "DEFINE TOKEN jwt ON DATABASE TYPE HS512 VALUE 'secret'"},
+		},
+		[]string{"user"},
+		[]tableDefinitions{{
+			Fields: map[string]string{
+				"name": "DEFINE FIELD name ON user TYPE string PERMISSIONS FULL",
+				"age":  "DEFINE FIELD age ON user TYPE int PERMISSIONS FULL",
+			},
+		}},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 1, len(schema.Tables))
+	assert.Equal(t, "age", schema.Tables[0].Fields[0].Name)
+	assert.Equal(t, "name", schema.Tables[0].Fields[1].Name)
+
+	assert.Equal(t, 2, len(schema.Accesses))
+	assert.Equal(t, "SCOPE", schema.Accesses[0].Kind)
+	assert.Equal(t, "TOKEN", schema.Accesses[1].Kind)
+}
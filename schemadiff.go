@@ -0,0 +1,963 @@
+package sdbc
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/go-surreal/sdbc/internal/surrealql"
+)
+
+// SchemaAction is the action of a SchemaChange.
+type SchemaAction string
+
+const (
+	SchemaActionCreate SchemaAction = "create"
+	SchemaActionUpdate SchemaAction = "update"
+	SchemaActionRemove SchemaAction = "remove"
+)
+
+// SchemaChange is a single change of a SchemaPlan.
+type SchemaChange struct {
+	Action SchemaAction
+
+	// Kind is the kind of definition, e.g. TABLE or FIELD.
+	Kind string
+
+	// Name is the name of the definition.
+	Name string
+
+	// Table is the table of a field, index or event.
+	Table string
+
+	// Statement is the DEFINE or REMOVE statement applying the change.
+	Statement string
+}
+
+// SchemaPlan holds the changes needed to align the schema of a
+// database with the desired schema, in the order they are applied.
+type SchemaPlan struct {
+	Changes []SchemaChange
+}
+
+// Empty reports whether the plan has no changes.
+func (p *SchemaPlan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// String returns the plan for review, one statement per line.
+// Statements are prefixed with + (create), ~ (update) or - (remove).
+func (p *SchemaPlan) String() string {
+	var builder strings.Builder
+
+	for _, change := range p.Changes {
+		switch change.Action {
+
+		case SchemaActionCreate:
+			builder.WriteString("+ ")
+
+		case SchemaActionUpdate:
+			builder.WriteString("~ ")
+
+		case SchemaActionRemove:
+			builder.WriteString("- ")
+		}
+
+		builder.WriteString(change.Statement + ";\n")
+	}
+
+	return builder.String()
+}
+
+// SchemaPlanOptions configures how a SchemaPlan is computed.
+type SchemaPlanOptions struct {
+	// Prune removes definitions that are not part of the desired schema.
+	// If not set, the plan only creates and updates definitions.
+	Prune bool
+}
+
+// ParseSchema parses the DEFINE statements of the given SurrealQL
+// (e.g. read from a .surql file) into a Schema, which can be passed
+// to Client.PlanSchema. Fields, indexes and events are added to the
+// definition of their table; tables that are not defined explicitly
+// are added with the default definition. Other statements are rejected.
+func ParseSchema(surql string) (*Schema, error) {
+	statements, err := splitStatements(surql)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidDefinition, err)
+	}
+
+	var schema Schema
+
+	for _, stmt := range statements {
+		if err := schema.addDefinition(stmt); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, tbl := range schema.Tables {
+		if tbl.Name == "" {
+			return nil, fmt.Errorf("%w: missing table of field, index or event", ErrInvalidDefinition)
+		}
+	}
+
+	return &schema, nil
+}
+
+// addDefinition parses the DEFINE statement and adds it to the schema.
+func (s *Schema) addDefinition(stmt string) error {
+	words, err := splitWords(stmt)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidDefinition, err)
+	}
+
+	if len(words) < 2 || !strings.EqualFold(words[0], "DEFINE") {
+		return fmt.Errorf("%w: only DEFINE statements are supported: %s", ErrInvalidDefinition, stmt)
+	}
+
+	switch strings.ToUpper(words[1]) {
+
+	case "TABLE":
+		def, err := parseTable(stmt)
+		if err != nil {
+			return err
+		}
+
+		table := s.table(def.Name)
+		def.Fields, def.Indexes, def.Events = table.Fields, table.Indexes, table.Events
+		*table = def
+
+		return nil
+
+	case "FIELD":
+		def, err := parseField(stmt)
+		if err != nil {
+			return err
+		}
+
+		table := s.table(def.Table)
+		table.Fields = append(table.Fields, def)
+
+		return nil
+
+	case "INDEX":
+		def, err := parseIndex(stmt)
+		if err != nil {
+			return err
+		}
+
+		table := s.table(def.Table)
+		table.Indexes = append(table.Indexes, def)
+
+		return nil
+
+	case "EVENT":
+		def, err := parseEvent(stmt)
+		if err != nil {
+			return err
+		}
+
+		table := s.table(def.Table)
+		table.Events = append(table.Events, def)
+
+		return nil
+
+	case "ANALYZER":
+		return appendDefinition(&s.Analyzers, stmt, parseAnalyzer)
+
+	case "FUNCTION":
+		return appendDefinition(&s.Functions, stmt, parseFunction)
+
+	case "PARAM":
+		return appendDefinition(&s.Params, stmt, parseParam)
+
+	case "ACCESS", "SCOPE", "TOKEN":
+		return appendDefinition(&s.Accesses, stmt, parseAccess)
+
+	case "USER":
+		return appendDefinition(&s.Users, stmt, parseUser)
+
+	default:
+		return fmt.Errorf("%w: unsupported definition: %s", ErrInvalidDefinition, stmt)
+	}
+}
+
+// table returns the definition of the table with the given name.
+// If there is none, a definition without any clauses is added.
+func (s *Schema) table(name string) *TableDefinition {
+	for index := range s.Tables {
+		if s.Tables[index].Name == name {
+			return &s.Tables[index]
+		}
+	}
+
+	s.Tables = append(s.Tables, TableDefinition{Name: name})
+
+	return &s.Tables[len(s.Tables)-1]
+}
+
+func appendDefinition[T any](defs *[]T, stmt string, parse func(def string) (T, error)) error {
+	def, err := parse(stmt)
+	if err != nil {
+		return err
+	}
+
+	*defs = append(*defs, def)
+
+	return nil
+}
+
+// PlanSchema compares the desired schema with the schema of the selected
+// database and returns the changes needed to align them. The desired schema
+// can be declared as Go values or parsed from SurrealQL with ParseSchema.
+//
+// If a definition of the desired schema has its Definition set, that
+// statement is used to create or update it; otherwise, the statement is
+// built from the other fields. Definitions are compared by their parsed
+// parts, where expressions are compared as written (ignoring whitespace)
+// and omitted permissions or table types default to those of the server.
+// To avoid needless updates, write expressions the way the server reports
+// them (see Client.Schema). Access methods and users are not compared,
+// as their definitions contain secrets.
+//
+// Changed definitions are updated with DEFINE ... OVERWRITE, which requires
+// SurrealDB 2.0. For older versions, the plain DEFINE statement is used,
+// as those replace existing definitions.
+func (c *Client) PlanSchema(ctx context.Context, desired *Schema, opts SchemaPlanOptions) (*SchemaPlan, error) {
+	current, err := c.Schema(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	overwrite := c.requireVersion("define overwrite", version2) == nil
+
+	return diffSchema(current, desired, opts, overwrite)
+}
+
+// ApplySchema applies the changes of the plan within a single transaction.
+func (c *Client) ApplySchema(ctx context.Context, plan *SchemaPlan) error {
+	if plan.Empty() {
+		return nil
+	}
+
+	var query strings.Builder
+
+	query.WriteString("BEGIN TRANSACTION;\n")
+
+	for _, change := range plan.Changes {
+		query.WriteString(change.Statement + ";\n")
+	}
+
+	query.WriteString("COMMIT TRANSACTION;")
+
+	if _, err := c.queryResults(ctx, query.String(), nil); err != nil {
+		return fmt.Errorf("could not apply schema: %w", err)
+	}
+
+	return nil
+}
+
+//
+// -- DIFF
+//
+
+// schemaEntry is a single definition of a schema, as compared by diffSchema.
+type schemaEntry struct {
+	kind      string
+	name      string
+	table     string
+	canonical string // normalized definition used for comparison
+	define    string // definition following DEFINE <kind> (and OVERWRITE)
+	remove    string // statement removing the definition
+}
+
+func (e schemaEntry) key() string {
+	return e.kind + " " + e.table + " " + e.name
+}
+
+func (e schemaEntry) change(action SchemaAction, overwrite bool) SchemaChange {
+	change := SchemaChange{Action: action, Kind: e.kind, Name: e.name, Table: e.table}
+
+	switch {
+
+	case action == SchemaActionRemove:
+		change.Statement = e.remove
+
+	case action == SchemaActionUpdate && overwrite:
+		change.Statement = "DEFINE " + e.kind + " OVERWRITE " + e.define
+
+	default:
+		change.Statement = "DEFINE " + e.kind + " " + e.define
+	}
+
+	return change
+}
+
+// diffSchema computes the plan to align the current with the desired schema.
+// Definitions are created in the order of their dependencies (e.g. analyzers
+// before indexes) and removed in reverse order, before any other change.
+func diffSchema(current, desired *Schema, opts SchemaPlanOptions, overwrite bool) (*SchemaPlan, error) {
+	currentEntries, err := schemaEntries(current)
+	if err != nil {
+		return nil, err
+	}
+
+	desiredEntries, err := schemaEntries(desired)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := make(map[string]schemaEntry, len(currentEntries))
+	for _, entry := range currentEntries {
+		existing[entry.key()] = entry
+	}
+
+	wanted := make(map[string]bool, len(desiredEntries))
+	for _, entry := range desiredEntries {
+		wanted[entry.key()] = true
+	}
+
+	var plan SchemaPlan
+
+	if opts.Prune {
+		for _, entry := range slices.Backward(currentEntries) {
+			if wanted[entry.key()] {
+				continue
+			}
+
+			// Removing a table removes its fields, indexes and events.
+			if entry.table != "" && !wanted[schemaEntry{kind: "TABLE", name: entry.table}.key()] {
+				continue
+			}
+
+			plan.Changes = append(plan.Changes, entry.change(SchemaActionRemove, overwrite))
+		}
+	}
+
+	for _, entry := range desiredEntries {
+		prev, ok := existing[entry.key()]
+
+		switch {
+
+		case !ok:
+			plan.Changes = append(plan.Changes, entry.change(SchemaActionCreate, overwrite))
+
+		case prev.canonical != entry.canonical:
+			plan.Changes = append(plan.Changes, entry.change(SchemaActionUpdate, overwrite))
+		}
+	}
+
+	return &plan, nil
+}
+
+// schemaEntries returns the compared definitions of the schema in
+// the order of their dependencies. Views are defined after tables.
+func schemaEntries(schema *Schema) ([]schemaEntry, error) {
+	entries, err := appendEntries(nil, schema.Analyzers, analyzerEntry)
+	if err != nil {
+		return nil, err
+	}
+
+	if entries, err = appendEntries(entries, schema.Functions, functionEntry); err != nil {
+		return nil, err
+	}
+
+	if entries, err = appendEntries(entries, schema.Params, paramEntry); err != nil {
+		return nil, err
+	}
+
+	tables := make([]TableDefinition, len(schema.Tables))
+
+	for index, def := range schema.Tables {
+		if def.Definition != "" {
+			parsed, err := parseTable(def.Definition)
+			if err != nil {
+				return nil, err
+			}
+
+			parsed.Fields, parsed.Indexes, parsed.Events = def.Fields, def.Indexes, def.Events
+			def = parsed
+		}
+
+		tables[index] = def
+	}
+
+	slices.SortStableFunc(tables, func(a, b TableDefinition) int {
+		switch {
+
+		case a.View == "" && b.View != "":
+			return -1
+
+		case a.View != "" && b.View == "":
+			return 1
+
+		default:
+			return 0
+		}
+	})
+
+	for _, def := range tables {
+		entries = append(entries, tableEntry(def))
+	}
+
+	for _, table := range tables {
+		if entries, err = appendEntries(entries, table.Fields, func(def FieldDefinition) (schemaEntry, error) {
+			return fieldEntry(table.Name, def)
+		}); err != nil {
+			return nil, err
+		}
+
+		if entries, err = appendEntries(entries, table.Indexes, func(def IndexDefinition) (schemaEntry, error) {
+			return indexEntry(table.Name, def)
+		}); err != nil {
+			return nil, err
+		}
+
+		if entries, err = appendEntries(entries, table.Events, func(def EventDefinition) (schemaEntry, error) {
+			return eventEntry(table.Name, def)
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	return entries, nil
+}
+
+func appendEntries[T any](
+	entries []schemaEntry, defs []T, entry func(def T) (schemaEntry, error),
+) (
+	[]schemaEntry, error,
+) {
+	for _, def := range defs {
+		next, err := entry(def)
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, next)
+	}
+
+	return entries, nil
+}
+
+//
+// -- ENTRIES
+//
+
+func tableEntry(def TableDefinition) schemaEntry {
+	define := renderTable(def)
+	if def.Definition != "" {
+		define = definitionText(def.Definition)
+	}
+
+	norm := def
+	norm.Type = compact(strings.ToUpper(norm.Type))
+	norm.View = compact(norm.View)
+	norm.Changefeed = compact(norm.Changefeed)
+	norm.Permissions = normalizePermissions(norm.Permissions, PermissionNone)
+
+	if norm.Type == "" {
+		norm.Type = "ANY"
+	}
+
+	return schemaEntry{
+		kind:      "TABLE",
+		name:      def.Name,
+		canonical: renderTable(norm),
+		define:    define,
+		remove:    "REMOVE TABLE " + surrealql.EscapeIdent(def.Name),
+	}
+}
+
+func fieldEntry(table string, def FieldDefinition) (schemaEntry, error) {
+	if def.Definition != "" {
+		parsed, err := parseField(def.Definition)
+		if err != nil {
+			return schemaEntry{}, err
+		}
+
+		def = parsed
+	}
+
+	if def.Table == "" {
+		def.Table = table
+	}
+
+	define := renderField(def)
+	if def.Definition != "" {
+		define = definitionText(def.Definition)
+	}
+
+	norm := def
+	norm.Kind = compact(norm.Kind)
+	norm.Default = compact(norm.Default)
+	norm.Value = compact(norm.Value)
+	norm.Assert = compact(norm.Assert)
+	norm.Permissions = normalizePermissions(norm.Permissions, PermissionFull)
+
+	return schemaEntry{
+		kind:      "FIELD",
+		name:      def.Name,
+		table:     table,
+		canonical: renderField(norm),
+		define:    define,
+		remove:    "REMOVE FIELD " + renderFieldPath(def.Name) + " ON " + surrealql.EscapeIdent(table),
+	}, nil
+}
+
+func indexEntry(table string, def IndexDefinition) (schemaEntry, error) {
+	if def.Definition != "" {
+		parsed, err := parseIndex(def.Definition)
+		if err != nil {
+			return schemaEntry{}, err
+		}
+
+		def = parsed
+	}
+
+	if def.Table == "" {
+		def.Table = table
+	}
+
+	define := renderIndex(def)
+	if def.Definition != "" {
+		define = definitionText(def.Definition)
+	}
+
+	norm := def
+	norm.Kind = compact(norm.Kind)
+
+	return schemaEntry{
+		kind:      "INDEX",
+		name:      def.Name,
+		table:     table,
+		canonical: renderIndex(norm),
+		define:    define,
+		remove:    "REMOVE INDEX " + surrealql.EscapeIdent(def.Name) + " ON " + surrealql.EscapeIdent(table),
+	}, nil
+}
+
+func eventEntry(table string, def EventDefinition) (schemaEntry, error) {
+	if def.Definition != "" {
+		parsed, err := parseEvent(def.Definition)
+		if err != nil {
+			return schemaEntry{}, err
+		}
+
+		def = parsed
+	}
+
+	if def.Table == "" {
+		def.Table = table
+	}
+
+	define := renderEvent(def)
+	if def.Definition != "" {
+		define = definitionText(def.Definition)
+	}
+
+	norm := def
+	norm.When = compact(norm.When)
+	norm.Then = compact(norm.Then)
+
+	if norm.When == "" {
+		norm.When = "true"
+	}
+
+	return schemaEntry{
+		kind:      "EVENT",
+		name:      def.Name,
+		table:     table,
+		canonical: renderEvent(norm),
+		define:    define,
+		remove:    "REMOVE EVENT " + surrealql.EscapeIdent(def.Name) + " ON " + surrealql.EscapeIdent(table),
+	}, nil
+}
+
+func analyzerEntry(def AnalyzerDefinition) (schemaEntry, error) {
+	if def.Definition != "" {
+		parsed, err := parseAnalyzer(def.Definition)
+		if err != nil {
+			return schemaEntry{}, err
+		}
+
+		def = parsed
+	}
+
+	define := renderAnalyzer(def)
+	if def.Definition != "" {
+		define = definitionText(def.Definition)
+	}
+
+	norm := def
+	norm.Tokenizers = compactAll(norm.Tokenizers)
+	norm.Filters = compactAll(norm.Filters)
+
+	return schemaEntry{
+		kind:      "ANALYZER",
+		name:      def.Name,
+		canonical: renderAnalyzer(norm),
+		define:    define,
+		remove:    "REMOVE ANALYZER " + surrealql.EscapeIdent(def.Name),
+	}, nil
+}
+
+func functionEntry(def FunctionDefinition) (schemaEntry, error) {
+	if def.Definition != "" {
+		parsed, err := parseFunction(def.Definition)
+		if err != nil {
+			return schemaEntry{}, err
+		}
+
+		def = parsed
+	}
+
+	if !strings.HasPrefix(def.Name, "fn::") {
+		def.Name = "fn::" + def.Name
+	}
+
+	define := renderFunction(def)
+	if def.Definition != "" {
+		define = definitionText(def.Definition)
+	}
+
+	norm := def
+	norm.Args = slices.Clone(norm.Args)
+	norm.Returns = compact(norm.Returns)
+	norm.Body = compact(norm.Body)
+	norm.Permissions = normalizePermission(norm.Permissions, PermissionFull)
+
+	for index := range norm.Args {
+		norm.Args[index].Kind = compact(norm.Args[index].Kind)
+	}
+
+	return schemaEntry{
+		kind:      "FUNCTION",
+		name:      def.Name,
+		canonical: renderFunction(norm),
+		define:    define,
+		remove:    "REMOVE FUNCTION " + def.Name,
+	}, nil
+}
+
+func paramEntry(def ParamDefinition) (schemaEntry, error) {
+	if def.Definition != "" {
+		parsed, err := parseParam(def.Definition)
+		if err != nil {
+			return schemaEntry{}, err
+		}
+
+		def = parsed
+	}
+
+	define := renderParam(def)
+	if def.Definition != "" {
+		define = definitionText(def.Definition)
+	}
+
+	norm := def
+	norm.Value = compact(norm.Value)
+	norm.Permissions = normalizePermission(norm.Permissions, PermissionFull)
+
+	return schemaEntry{
+		kind:      "PARAM",
+		name:      def.Name,
+		canonical: renderParam(norm),
+		define:    define,
+		remove:    "REMOVE PARAM $" + surrealql.EscapeIdent(def.Name),
+	}, nil
+}
+
+//
+// -- RENDER
+//
+
+// The render functions build the part of a DEFINE statement
+// following the kind of definition (e.g. DEFINE TABLE).
+
+func renderTable(def TableDefinition) string {
+	parts := []string{surrealql.EscapeIdent(def.Name)}
+
+	if def.Drop {
+		parts = append(parts, "DROP")
+	}
+
+	if def.Type != "" {
+		parts = append(parts, "TYPE "+def.Type)
+	}
+
+	if len(def.In) > 0 {
+		parts = append(parts, "IN "+renderAlternatives(def.In))
+	}
+
+	if len(def.Out) > 0 {
+		parts = append(parts, "OUT "+renderAlternatives(def.Out))
+	}
+
+	if def.Schemafull {
+		parts = append(parts, "SCHEMAFULL")
+	} else {
+		parts = append(parts, "SCHEMALESS")
+	}
+
+	if def.View != "" {
+		parts = append(parts, "AS "+def.View)
+	}
+
+	if def.Changefeed != "" {
+		parts = append(parts, "CHANGEFEED "+def.Changefeed)
+	}
+
+	parts = appendComment(parts, def.Comment)
+
+	return strings.Join(appendPermissions(parts, def.Permissions), " ")
+}
+
+func renderField(def FieldDefinition) string {
+	parts := []string{renderFieldPath(def.Name), "ON " + surrealql.EscapeIdent(def.Table)}
+
+	if def.Flexible {
+		parts = append(parts, "FLEXIBLE")
+	}
+
+	if def.Kind != "" {
+		parts = append(parts, "TYPE "+def.Kind)
+	}
+
+	if def.Default != "" {
+		if def.DefaultAlways {
+			parts = append(parts, "DEFAULT ALWAYS "+def.Default)
+		} else {
+			parts = append(parts, "DEFAULT "+def.Default)
+		}
+	}
+
+	if def.Readonly {
+		parts = append(parts, "READONLY")
+	}
+
+	if def.Value != "" {
+		parts = append(parts, "VALUE "+def.Value)
+	}
+
+	if def.Assert != "" {
+		parts = append(parts, "ASSERT "+def.Assert)
+	}
+
+	parts = appendComment(parts, def.Comment)
+
+	return strings.Join(appendPermissions(parts, def.Permissions), " ")
+}
+
+func renderIndex(def IndexDefinition) string {
+	fields := make([]string, len(def.Fields))
+	for index, field := range def.Fields {
+		fields[index] = renderFieldPath(field)
+	}
+
+	parts := []string{surrealql.EscapeIdent(def.Name), "ON " + surrealql.EscapeIdent(def.Table), "FIELDS " + strings.Join(fields, ", ")}
+
+	if def.Kind != "" {
+		parts = append(parts, def.Kind)
+	}
+
+	return strings.Join(appendComment(parts, def.Comment), " ")
+}
+
+func renderEvent(def EventDefinition) string {
+	parts := []string{surrealql.EscapeIdent(def.Name), "ON " + surrealql.EscapeIdent(def.Table)}
+
+	if def.When != "" {
+		parts = append(parts, "WHEN "+def.When)
+	}
+
+	parts = append(parts, "THEN "+def.Then)
+
+	return strings.Join(appendComment(parts, def.Comment), " ")
+}
+
+func renderAnalyzer(def AnalyzerDefinition) string {
+	parts := []string{surrealql.EscapeIdent(def.Name)}
+
+	if def.Function != "" {
+		parts = append(parts, "FUNCTION "+def.Function)
+	}
+
+	if len(def.Tokenizers) > 0 {
+		parts = append(parts, "TOKENIZERS "+strings.Join(def.Tokenizers, ","))
+	}
+
+	if len(def.Filters) > 0 {
+		parts = append(parts, "FILTERS "+strings.Join(def.Filters, ","))
+	}
+
+	return strings.Join(appendComment(parts, def.Comment), " ")
+}
+
+func renderFunction(def FunctionDefinition) string {
+	args := make([]string, len(def.Args))
+	for index, arg := range def.Args {
+		args[index] = "$" + surrealql.EscapeIdent(arg.Name) + ": " + arg.Kind
+	}
+
+	parts := []string{def.Name + "(" + strings.Join(args, ", ") + ")"}
+
+	if def.Returns != "" {
+		parts = append(parts, "-> "+def.Returns)
+	}
+
+	body := def.Body
+	if body == "" {
+		body = "{}"
+	}
+
+	parts = appendComment(append(parts, body), def.Comment)
+
+	if def.Permissions != "" {
+		parts = append(parts, "PERMISSIONS "+string(def.Permissions))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+func renderParam(def ParamDefinition) string {
+	parts := []string{"$" + surrealql.EscapeIdent(def.Name), "VALUE " + def.Value}
+
+	parts = appendComment(parts, def.Comment)
+
+	if def.Permissions != "" {
+		parts = append(parts, "PERMISSIONS "+string(def.Permissions))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// renderFieldPath escapes the parts of the field path,
+// except for the wildcards of array fields (e.g. tags.*).
+func renderFieldPath(path string) string {
+	parts := strings.Split(path, ".")
+
+	for index, part := range parts {
+		if part != "*" && part != "[*]" {
+			parts[index] = surrealql.EscapeIdent(part)
+		}
+	}
+
+	return strings.Join(parts, ".")
+}
+
+func renderAlternatives(tables []string) string {
+	escaped := make([]string, len(tables))
+	for index, table := range tables {
+		escaped[index] = surrealql.EscapeIdent(table)
+	}
+
+	return strings.Join(escaped, "|")
+}
+
+func appendComment(parts []string, comment string) []string {
+	if comment == "" {
+		return parts
+	}
+
+	return append(parts, keywordComment+" "+quoteString(comment, '\''))
+}
+
+// appendPermissions appends the permissions clause. Permissions shared by
+// several operations are combined, e.g. FOR select, update FULL.
+func appendPermissions(parts []string, perms Permissions) []string {
+	kinds := []struct {
+		name string
+		perm Permission
+	}{
+		{"select", perms.Select}, {"create", perms.Create},
+		{"update", perms.Update}, {"delete", perms.Delete},
+	}
+
+	if perms.Select != "" && perms.Select == perms.Create &&
+		perms.Select == perms.Update && perms.Select == perms.Delete {
+		return append(parts, keywordPermissions+" "+string(perms.Select))
+	}
+
+	var (
+		clauses []string
+		done    = map[Permission]bool{}
+	)
+
+	for _, kind := range kinds {
+		if kind.perm == "" || done[kind.perm] {
+			continue
+		}
+
+		done[kind.perm] = true
+
+		var names []string
+
+		for _, other := range kinds {
+			if other.perm == kind.perm {
+				names = append(names, other.name)
+			}
+		}
+
+		clauses = append(clauses, "FOR "+strings.Join(names, ", ")+" "+string(kind.perm))
+	}
+
+	if len(clauses) == 0 {
+		return parts
+	}
+
+	return append(parts, keywordPermissions+" "+strings.Join(clauses, ", "))
+}
+
+//
+// -- NORMALIZE
+//
+
+// definitionText returns the definition following DEFINE <kind>,
+// without the OVERWRITE or IF NOT EXISTS clauses.
+func definitionText(def string) string {
+	words, err := splitWords(def)
+	if err != nil || len(words) < 2 {
+		return def
+	}
+
+	// The definition has been parsed before, so the kind is known to be valid.
+	rest, err := definitionWords(def, words[1])
+	if err != nil {
+		return def
+	}
+
+	return strings.Join(rest, " ")
+}
+
+func normalizePermissions(perms Permissions, fallback Permission) Permissions {
+	return Permissions{
+		Select: normalizePermission(perms.Select, fallback),
+		Create: normalizePermission(perms.Create, fallback),
+		Update: normalizePermission(perms.Update, fallback),
+		Delete: normalizePermission(perms.Delete, fallback),
+	}
+}
+
+func normalizePermission(perm, fallback Permission) Permission {
+	if perm == "" {
+		return fallback
+	}
+
+	return Permission(compact(string(perm)))
+}
+
+// compact collapses all whitespace to single spaces.
+func compact(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
+
+func compactAll(texts []string) []string {
+	out := make([]string, len(texts))
+	for index, text := range texts {
+		out[index] = compact(text)
+	}
+
+	return out
+}
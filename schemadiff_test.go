@@ -0,0 +1,315 @@
+package sdbc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestSchemaPlan(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	client, cleanup := prepareSurreal(ctx, t)
+	defer cleanup()
+
+	_, err := client.Query(ctx, `
+		DEFINE TABLE user SCHEMAFULL;
+		DEFINE FIELD email ON user TYPE string;
+		DEFINE FIELD legacy ON user TYPE option<string>;
+		DEFINE TABLE old;
+	`, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	desired, err := ParseSchema(`
+		DEFINE TABLE user SCHEMAFULL;
+		DEFINE FIELD email ON user TYPE string ASSERT string::is::email($value);
+		DEFINE INDEX email ON user FIELDS email UNIQUE;
+		DEFINE PARAM $limit VALUE 10;
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	plan, err := client.PlanSchema(ctx, desired, SchemaPlanOptions{Prune: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, ""+
+		"- REMOVE TABLE old;\n"+
+		"- REMOVE FIELD legacy ON user;\n"+
+		"+ DEFINE PARAM $limit VALUE 10;\n"+
+		"~ DEFINE FIELD OVERWRITE email ON user TYPE string ASSERT string::is::email($value);\n"+
+		"+ DEFINE INDEX email ON user FIELDS email UNIQUE;\n",
+		plan.String())
+
+	if err := client.ApplySchema(ctx, plan); err != nil {
+		t.Fatal(err)
+	}
+
+	// The database now matches the desired schema.
+
+	plan, err = client.PlanSchema(ctx, desired, SchemaPlanOptions{Prune: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Check(t, plan.Empty(), plan.String())
+}
+
+func TestParseSchema(t *testing.T) {
+	t.Parallel()
+
+	schema, err := ParseSchema(`
+		-- fields may be declared before their table
+		DEFINE FIELD email ON user TYPE string;
+		DEFINE TABLE user SCHEMAFULL;
+		DEFINE INDEX email ON TABLE user FIELDS email UNIQUE;
+		DEFINE EVENT created ON post THEN (CREATE log);
+		DEFINE ANALYZER simple TOKENIZERS blank;
+		DEFINE FUNCTION fn::greet($name: string) { RETURN 'Hello ' + $name; };
+		DEFINE PARAM $limit VALUE 10;
+		DEFINE USER admin ON DATABASE PASSWORD 'secret' ROLES OWNER;
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 2, len(schema.Tables))
+
+	user, post := schema.Tables[0], schema.Tables[1]
+
+	assert.Equal(t, "user", user.Name)
+	assert.Check(t, user.Schemafull)
+	assert.Equal(t, "DEFINE TABLE user SCHEMAFULL", user.Definition)
+	assert.Equal(t, 1, len(user.Fields))
+	assert.Equal(t, 1, len(user.Indexes))
+
+	assert.Equal(t, "post", post.Name)
+	assert.Equal(t, "", post.Definition)
+	assert.Equal(t, 1, len(post.Events))
+
+	assert.Equal(t, 1, len(schema.Analyzers))
+	assert.Equal(t, 1, len(schema.Functions))
+	assert.Equal(t, 1, len(schema.Params))
+	assert.Equal(t, 1, len(schema.Users))
+
+	for _, surql := range []string{
+		"DEFINE TABLE user; CREATE user:one;",
+		"DEFINE NAMESPACE test;",
+		"DEFINE TABLE 'user",
+	} {
+		_, err := ParseSchema(surql)
+		assert.Check(t, errors.Is(err, ErrInvalidDefinition), "%s: %v", surql, err)
+	}
+}
+
+func TestDiffSchema(t *testing.T) {
+	t.Parallel()
+
+	// The current schema as reported by the server.
+	current := &Schema{
+		Tables: []TableDefinition{
+			{
+				Name:        "user",
+				Type:        "ANY",
+				Schemafull:  true,
+				Permissions: Permissions{Select: PermissionNone, Create: PermissionNone, Update: PermissionNone, Delete: PermissionNone},
+				Definition:  "DEFINE TABLE user TYPE ANY SCHEMAFULL PERMISSIONS NONE",
+				Fields: []FieldDefinition{
+					{
+						Name: "name", Table: "user", Kind: "string",
+						Permissions: Permissions{Select: PermissionFull, Create: PermissionFull, Update: PermissionFull, Delete: PermissionFull},
+						Definition:  "DEFINE FIELD name ON user TYPE string PERMISSIONS FULL",
+					},
+					{
+						Name: "age", Table: "user", Kind: "int",
+						Permissions: Permissions{Select: PermissionFull, Create: PermissionFull, Update: PermissionFull, Delete: PermissionFull},
+						Definition:  "DEFINE FIELD age ON user TYPE int PERMISSIONS FULL",
+					},
+				},
+			},
+			{
+				Name:       "old",
+				Definition: "DEFINE TABLE old TYPE ANY SCHEMALESS PERMISSIONS NONE",
+				Fields: []FieldDefinition{
+					{Name: "x", Table: "old", Definition: "DEFINE FIELD x ON old PERMISSIONS FULL"},
+				},
+			},
+		},
+		Functions: []FunctionDefinition{
+			{Name: "fn::old", Body: "{ RETURN 1; }", Definition: "DEFINE FUNCTION fn::old() { RETURN 1; } PERMISSIONS FULL"},
+		},
+	}
+
+	// The desired schema declared as Go values, where defaults are omitted.
+	desired := &Schema{
+		Tables: []TableDefinition{
+			{
+				Name:       "user",
+				Schemafull: true,
+				Fields: []FieldDefinition{
+					{Name: "name", Kind: "string"},
+					{Name: "age", Kind: "int", Assert: "$value >= 0"},
+					{Name: "tags.*", Kind: "string"},
+				},
+				Indexes: []IndexDefinition{
+					{Name: "name", Fields: []string{"name"}, Kind: "UNIQUE"},
+				},
+			},
+			{
+				Name:        "recent",
+				View:        "SELECT * FROM user",
+				Permissions: Permissions{Select: PermissionFull},
+			},
+		},
+		Functions: []FunctionDefinition{
+			{Name: "greet", Args: []FunctionArg{{Name: "name", Kind: "string"}}, Body: "{ RETURN $name; }"},
+		},
+	}
+
+	plan, err := diffSchema(current, desired, SchemaPlanOptions{}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, ""+
+		"+ DEFINE FUNCTION fn::greet($name: string) { RETURN $name; };\n"+
+		"+ DEFINE TABLE recent SCHEMALESS AS SELECT * FROM user PERMISSIONS FOR select FULL;\n"+
+		"~ DEFINE FIELD OVERWRITE age ON user TYPE int ASSERT $value >= 0;\n"+
+		"+ DEFINE FIELD tags.* ON user TYPE string;\n"+
+		"+ DEFINE INDEX name ON user FIELDS name UNIQUE;\n",
+		plan.String())
+
+	assert.Equal(t, SchemaActionUpdate, plan.Changes[2].Action)
+	assert.Equal(t, "FIELD", plan.Changes[2].Kind)
+	assert.Equal(t, "age", plan.Changes[2].Name)
+	assert.Equal(t, "user", plan.Changes[2].Table)
+
+	// Removals come first; the fields of removed tables are not removed separately.
+
+	plan, err = diffSchema(current, desired, SchemaPlanOptions{Prune: true}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, ""+
+		"- REMOVE TABLE old;\n"+
+		"- REMOVE FUNCTION fn::old;\n"+
+		"+ DEFINE FUNCTION fn::greet($name: string) { RETURN $name; };\n"+
+		"+ DEFINE TABLE recent SCHEMALESS AS SELECT * FROM user PERMISSIONS FOR select FULL;\n"+
+		"~ DEFINE FIELD age ON user TYPE int ASSERT $value >= 0;\n"+
+		"+ DEFINE FIELD tags.* ON user TYPE string;\n"+
+		"+ DEFINE INDEX name ON user FIELDS name UNIQUE;\n",
+		plan.String())
+
+	// A schema without changes results in an empty plan.
+
+	plan, err = diffSchema(current, current, SchemaPlanOptions{Prune: true}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Check(t, plan.Empty(), plan.String())
+}
+
+func TestDiffSchemaDefinitions(t *testing.T) {
+	t.Parallel()
+
+	current, err := ParseSchema(`
+		DEFINE TABLE user TYPE NORMAL SCHEMAFULL PERMISSIONS NONE;
+		DEFINE FIELD email ON user TYPE string PERMISSIONS FULL;
+		DEFINE EVENT created ON user WHEN true THEN (CREATE log);
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Whitespace and omitted defaults are ignored, the given definitions are kept as written.
+	desired, err := ParseSchema(`
+		DEFINE TABLE IF NOT EXISTS user TYPE NORMAL SCHEMAFULL;
+		DEFINE FIELD email ON user TYPE   string;
+		DEFINE EVENT created ON user THEN (CREATE log);
+		DEFINE FIELD name ON user TYPE string COMMENT 'the name' -- comments are dropped
+			PERMISSIONS FOR select FULL FOR create, update WHERE $auth.admin = true FOR delete NONE;
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	plan, err := diffSchema(current, desired, SchemaPlanOptions{}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, ""+
+		"+ DEFINE FIELD name ON user TYPE string COMMENT 'the name' "+
+		"PERMISSIONS FOR select FULL FOR create, update WHERE $auth.admin = true FOR delete NONE;\n",
+		plan.String())
+}
+
+func TestRenderDefinitions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		render func() string
+		want   string
+	}{
+		{
+			render: func() string {
+				return renderTable(TableDefinition{
+					Name: "likes", Type: "RELATION", In: []string{"user"}, Out: []string{"post", "my-table"},
+					Drop: true, Changefeed: "1h", Comment: "it's",
+					Permissions: Permissions{Select: PermissionFull, Create: "WHERE $auth", Update: "WHERE $auth", Delete: PermissionNone},
+				})
+			},
+			want: "likes DROP TYPE RELATION IN user OUT post|`my-table` SCHEMALESS CHANGEFEED 1h COMMENT 'it\\'s' " +
+				"PERMISSIONS FOR select FULL, FOR create, update WHERE $auth, FOR delete NONE",
+		},
+		{
+			render: func() string {
+				return renderField(FieldDefinition{
+					Name: "created-at", Table: "user", Flexible: true, Kind: "datetime",
+					Default: "time::now()", DefaultAlways: true, Readonly: true, Value: "$value", Assert: "$value != NONE",
+				})
+			},
+			want: "`created-at` ON user FLEXIBLE TYPE datetime DEFAULT ALWAYS time::now() READONLY VALUE $value ASSERT $value != NONE",
+		},
+		{
+			render: func() string {
+				return renderEvent(EventDefinition{Name: "e", Table: "user", Then: "{ RETURN 1; }", Comment: "c"})
+			},
+			want: "e ON user THEN { RETURN 1; } COMMENT 'c'",
+		},
+		{
+			render: func() string {
+				return renderAnalyzer(AnalyzerDefinition{
+					Name: "ascii", Function: "fn::stem", Tokenizers: []string{"blank", "class"}, Filters: []string{"ascii", "snowball(english)"},
+				})
+			},
+			want: "ascii FUNCTION fn::stem TOKENIZERS blank,class FILTERS ascii,snowball(english)",
+		},
+		{
+			render: func() string {
+				return renderFunction(FunctionDefinition{Name: "fn::noop", Returns: "none", Permissions: PermissionNone})
+			},
+			want: "fn::noop() -> none {} PERMISSIONS NONE",
+		},
+		{
+			render: func() string {
+				return renderParam(ParamDefinition{Name: "limit", Value: "10", Comment: "max", Permissions: PermissionFull})
+			},
+			want: "$limit VALUE 10 COMMENT 'max' PERMISSIONS FULL",
+		},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.want, test.render())
+	}
+}
@@ -3,17 +3,22 @@ package sdbc
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
+	"net/http/httptest"
 	"regexp"
+	"strings"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/brianvoe/gofakeit/v7"
+	"github.com/coder/websocket"
 	"github.com/docker/docker/api/types/container"
+	"github.com/fxamacker/cbor/v2"
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/wait"
 )
@@ -300,3 +305,93 @@ func (t *testContext) setErr(err error) {
 
 	t.err = err
 }
+
+//
+// -- WEBSOCKET
+//
+
+// errBreakConnection can be returned by the handler of newWebsocketTestClient
+// to close the connection abnormally instead of answering the request.
+var errBreakConnection = errors.New("break connection")
+
+// newWebsocketTestClient returns a client connected to a fake server,
+// which answers each request with the result (or error) of the handler.
+func newWebsocketTestClient(
+	t *testing.T,
+	handler func(req request) (any, *responseError),
+	opts ...Option,
+) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := websocket.Accept(w, r, &websocket.AcceptOptions{Subprotocols: []string{"cbor"}})
+		if err != nil {
+			return
+		}
+
+		defer conn.CloseNow()
+
+		for {
+			_, data, err := conn.Read(r.Context())
+			if err != nil {
+				return
+			}
+
+			var req request
+
+			if err := cbor.Unmarshal(data, &req); err != nil {
+				return
+			}
+
+			result, resErr := handler(req)
+
+			if result == errBreakConnection {
+				_ = conn.Close(websocket.StatusInternalError, "broken")
+
+				return
+			}
+
+			raw, err := cbor.Marshal(result)
+			if err != nil {
+				return
+			}
+
+			data, err = cbor.Marshal(response{ID: req.ID, Result: raw, Error: resErr})
+			if err != nil {
+				return
+			}
+
+			if err := conn.Write(r.Context(), websocket.MessageBinary, data); err != nil {
+				return
+			}
+		}
+	}))
+
+	marshal, unmarshal := newTestCodec(t, nil)
+
+	client := &Client{
+		options:     applyOptions(opts),
+		marshal:     marshal,
+		unmarshal:   unmarshal,
+		conf:        Config{Host: strings.TrimPrefix(server.URL, "http://")},
+		requests:    newRequests(),
+		liveQueries: newLiveQueries(),
+		liveParams:  newLiveParams(),
+	}
+
+	client.connCtx, client.connCancel = context.WithCancel(context.Background())
+
+	if err := client.openWebsocket(); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		if err := client.Close(); err != nil {
+			t.Error(err)
+		}
+
+		server.Close()
+	})
+
+	return client
+}
@@ -0,0 +1,137 @@
+// Package sdbcotel provides an OpenTelemetry implementation of sdbc.Tracer.
+//
+// It creates a client span for each RPC request and a consumer span for each
+// live query notification, which is a child of the span active when the live
+// query was started:
+//
+//	client, err := sdbc.NewClient(ctx, conf, sdbc.WithTracer(sdbcotel.NewTracer()))
+package sdbcotel
+
+import (
+	"context"
+
+	"github.com/go-surreal/sdbc"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.30.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	instrumentationName = "github.com/go-surreal/sdbc/sdbcotel"
+
+	systemName = "surrealdb"
+
+	spanNameNotification = "live notification"
+)
+
+const (
+	AttrStatements       = attribute.Key("surrealdb.statements")
+	AttrResponseSize     = attribute.Key("surrealdb.response.size")
+	AttrServerDuration   = attribute.Key("surrealdb.server.duration")
+	AttrLiveID           = attribute.Key("surrealdb.live.id")
+	AttrLiveAction       = attribute.Key("surrealdb.live.action")
+	AttrNotificationSize = attribute.Key("surrealdb.notification.size")
+)
+
+var _ sdbc.Tracer = (*Tracer)(nil)
+
+type options struct {
+	provider trace.TracerProvider
+}
+
+type Option func(*options)
+
+// WithTracerProvider sets the provider used to create the tracer.
+// If not set, the global provider is used.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(o *options) {
+		o.provider = provider
+	}
+}
+
+// Tracer creates OpenTelemetry spans for the requests
+// and live query notifications of an sdbc.Client.
+type Tracer struct {
+	tracer trace.Tracer
+}
+
+// NewTracer creates a new tracer, which can be passed to sdbc.WithTracer.
+func NewTracer(opts ...Option) *Tracer {
+	conf := &options{}
+
+	for _, opt := range opts {
+		opt(conf)
+	}
+
+	if conf.provider == nil {
+		conf.provider = otel.GetTracerProvider()
+	}
+
+	return &Tracer{
+		tracer: conf.provider.Tracer(instrumentationName),
+	}
+}
+
+// StartRequest starts a client span named after the RPC method (e.g. query).
+// The span is ended with the size of the response, the execution time reported
+// by the server and the error status.
+func (t *Tracer) StartRequest(ctx context.Context, info sdbc.RequestInfo) (context.Context, func(sdbc.ResponseInfo)) {
+	attrs := []attribute.KeyValue{
+		semconv.DBSystemNameKey.String(systemName),
+		semconv.DBOperationName(info.Method),
+		semconv.DBNamespace(info.Namespace + "|" + info.Database),
+	}
+
+	if info.Statements > 0 {
+		attrs = append(attrs, AttrStatements.Int(info.Statements))
+	}
+
+	if info.Statements > 1 {
+		attrs = append(attrs, semconv.DBOperationBatchSize(info.Statements))
+	}
+
+	ctx, span := t.tracer.Start(ctx, info.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+
+	return ctx, func(res sdbc.ResponseInfo) {
+		span.SetAttributes(AttrResponseSize.Int(res.Size))
+
+		if res.ServerTime > 0 {
+			span.SetAttributes(AttrServerDuration.Float64(res.ServerTime.Seconds()))
+		}
+
+		endSpan(span, res.Err)
+	}
+}
+
+// StartNotification starts a consumer span for the notification, which is a
+// child of the span active when the live query was started. The span is ended
+// once the notification has been delivered or dropped.
+func (t *Tracer) StartNotification(ctx context.Context, info sdbc.NotificationInfo) func(err error) {
+	_, span := t.tracer.Start(ctx, spanNameNotification,
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			semconv.DBSystemNameKey.String(systemName),
+			AttrLiveID.String(info.LiveID),
+			AttrLiveAction.String(info.Action),
+			AttrNotificationSize.Int(info.Size),
+		),
+	)
+
+	return func(err error) {
+		endSpan(span, err)
+	}
+}
+
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
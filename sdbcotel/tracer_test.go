@@ -0,0 +1,101 @@
+package sdbcotel
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-surreal/sdbc"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"gotest.tools/v3/assert"
+)
+
+func TestStartRequest(t *testing.T) {
+	t.Parallel()
+
+	recorder := tracetest.NewSpanRecorder()
+	tracer := NewTracer(WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))))
+
+	ctx, end := tracer.StartRequest(context.Background(), sdbc.RequestInfo{
+		Method:     "query",
+		Namespace:  "ns",
+		Database:   "db",
+		Statements: 2,
+	})
+
+	assert.Check(t, trace.SpanFromContext(ctx).SpanContext().IsValid(), "span must be active in context")
+
+	end(sdbc.ResponseInfo{
+		Size:       42,
+		ServerTime: 1500 * time.Microsecond,
+		Err:        sdbc.ErrResponseNotOkay,
+	})
+
+	spans := recorder.Ended()
+	assert.Equal(t, 1, len(spans))
+
+	span := spans[0]
+
+	assert.Equal(t, "query", span.Name())
+	assert.Equal(t, trace.SpanKindClient, span.SpanKind())
+	assert.Equal(t, codes.Error, span.Status().Code)
+
+	attrs := attributes(span.Attributes())
+
+	assert.Equal(t, "surrealdb", attrs["db.system.name"].AsString())
+	assert.Equal(t, "query", attrs["db.operation.name"].AsString())
+	assert.Equal(t, "ns|db", attrs["db.namespace"].AsString())
+	assert.Equal(t, int64(2), attrs["db.operation.batch.size"].AsInt64())
+	assert.Equal(t, int64(2), attrs[AttrStatements].AsInt64())
+	assert.Equal(t, int64(42), attrs[AttrResponseSize].AsInt64())
+	assert.Equal(t, 0.0015, attrs[AttrServerDuration].AsFloat64())
+}
+
+func TestStartNotification(t *testing.T) {
+	t.Parallel()
+
+	recorder := tracetest.NewSpanRecorder()
+	tracer := NewTracer(WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))))
+
+	// The span of the live query request is the parent of its notifications.
+	liveCtx, endLive := tracer.StartRequest(context.Background(), sdbc.RequestInfo{Method: "live"})
+	endLive(sdbc.ResponseInfo{})
+
+	tracer.StartNotification(liveCtx, sdbc.NotificationInfo{LiveID: "id", Action: "CREATE", Size: 10})(nil)
+	tracer.StartNotification(liveCtx, sdbc.NotificationInfo{LiveID: "id", Action: "UPDATE"})(
+		sdbc.ErrLiveNotificationDropped,
+	)
+
+	spans := recorder.Ended()
+	assert.Equal(t, 3, len(spans))
+
+	live, created, updated := spans[0], spans[1], spans[2]
+
+	assert.Equal(t, codes.Unset, live.Status().Code)
+
+	assert.Equal(t, spanNameNotification, created.Name())
+	assert.Equal(t, trace.SpanKindConsumer, created.SpanKind())
+	assert.Equal(t, live.SpanContext().SpanID(), created.Parent().SpanID())
+	assert.Equal(t, codes.Unset, created.Status().Code)
+
+	attrs := attributes(created.Attributes())
+
+	assert.Equal(t, "id", attrs[AttrLiveID].AsString())
+	assert.Equal(t, "CREATE", attrs[AttrLiveAction].AsString())
+	assert.Equal(t, int64(10), attrs[AttrNotificationSize].AsInt64())
+
+	assert.Equal(t, codes.Error, updated.Status().Code)
+}
+
+func attributes(attrs []attribute.KeyValue) map[attribute.Key]attribute.Value {
+	out := make(map[attribute.Key]attribute.Value, len(attrs))
+	for _, attr := range attrs {
+		out[attr.Key] = attr.Value
+	}
+
+	return out
+}
@@ -0,0 +1,187 @@
+// Package sdbcprom provides a Prometheus implementation of sdbc.Metrics.
+//
+// The metrics are a prometheus.Collector, which has to be registered:
+//
+//	metrics := sdbcprom.NewMetrics()
+//	prometheus.MustRegister(metrics)
+//
+//	client, err := sdbc.NewClient(ctx, conf, sdbc.WithMetrics(metrics))
+package sdbcprom
+
+import (
+	"time"
+
+	"github.com/go-surreal/sdbc"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	defaultNamespace = "sdbc"
+
+	labelMethod = "method"
+)
+
+var (
+	_ sdbc.Metrics         = (*Metrics)(nil)
+	_ prometheus.Collector = (*Metrics)(nil)
+)
+
+type options struct {
+	namespace   string
+	buckets     []float64
+	constLabels prometheus.Labels
+}
+
+type Option func(*options)
+
+// WithNamespace sets the namespace (prefix) of the metric names.
+// If not set, the namespace is "sdbc".
+func WithNamespace(namespace string) Option {
+	return func(o *options) {
+		o.namespace = namespace
+	}
+}
+
+// WithBuckets sets the buckets (in seconds) of the request duration histogram.
+// If not set, prometheus.DefBuckets is used.
+func WithBuckets(buckets []float64) Option {
+	return func(o *options) {
+		o.buckets = buckets
+	}
+}
+
+// WithConstLabels sets labels added to all metrics,
+// e.g. to distinguish the metrics of multiple clients.
+func WithConstLabels(labels prometheus.Labels) Option {
+	return func(o *options) {
+		o.constLabels = labels
+	}
+}
+
+// Metrics records the metrics of an sdbc.Client as Prometheus metrics.
+type Metrics struct {
+	requestDuration      *prometheus.HistogramVec
+	requestErrors        *prometheus.CounterVec
+	requestsInFlight     prometheus.Gauge
+	bytesWritten         prometheus.Counter
+	bytesRead            prometheus.Counter
+	reconnects           prometheus.Counter
+	reconnectErrors      prometheus.Counter
+	droppedNotifications prometheus.Counter
+}
+
+// NewMetrics creates new metrics, which can be passed to sdbc.WithMetrics.
+// They must be registered with a prometheus.Registerer to be exported.
+func NewMetrics(opts ...Option) *Metrics {
+	conf := &options{
+		namespace: defaultNamespace,
+		buckets:   prometheus.DefBuckets,
+	}
+
+	for _, opt := range opts {
+		opt(conf)
+	}
+
+	counter := func(name, help string) prometheus.Counter {
+		return prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace:   conf.namespace,
+			Name:        name,
+			Help:        help,
+			ConstLabels: conf.constLabels,
+		})
+	}
+
+	return &Metrics{
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   conf.namespace,
+			Name:        "request_duration_seconds",
+			Help:        "Duration of RPC requests from sending until the response has been received.",
+			ConstLabels: conf.constLabels,
+			Buckets:     conf.buckets,
+		}, []string{labelMethod}),
+
+		requestErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   conf.namespace,
+			Name:        "request_errors_total",
+			Help:        "Number of failed RPC requests.",
+			ConstLabels: conf.constLabels,
+		}, []string{labelMethod}),
+
+		requestsInFlight: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace:   conf.namespace,
+			Name:        "requests_in_flight",
+			Help:        "Number of RPC requests waiting for a response.",
+			ConstLabels: conf.constLabels,
+		}),
+
+		bytesWritten: counter("websocket_written_bytes_total", "Number of bytes written to the websocket."),
+		bytesRead:    counter("websocket_read_bytes_total", "Number of bytes read from the websocket."),
+
+		reconnects:      counter("websocket_reconnects_total", "Number of attempted websocket reconnects."),
+		reconnectErrors: counter("websocket_reconnect_errors_total", "Number of failed websocket reconnects."),
+
+		droppedNotifications: counter(
+			"live_notifications_dropped_total",
+			"Number of live query notifications that could not be delivered.",
+		),
+	}
+}
+
+func (m *Metrics) ObserveRequest(method string, duration time.Duration, err error) {
+	m.requestDuration.WithLabelValues(method).Observe(duration.Seconds())
+
+	if err != nil {
+		m.requestErrors.WithLabelValues(method).Inc()
+	}
+}
+
+func (m *Metrics) SetRequestsInFlight(count int) {
+	m.requestsInFlight.Set(float64(count))
+}
+
+func (m *Metrics) AddBytesWritten(count int) {
+	m.bytesWritten.Add(float64(count))
+}
+
+func (m *Metrics) AddBytesRead(count int) {
+	m.bytesRead.Add(float64(count))
+}
+
+func (m *Metrics) IncReconnects(err error) {
+	m.reconnects.Inc()
+
+	if err != nil {
+		m.reconnectErrors.Inc()
+	}
+}
+
+func (m *Metrics) IncDroppedNotifications() {
+	m.droppedNotifications.Inc()
+}
+
+// Describe implements prometheus.Collector.
+func (m *Metrics) Describe(descs chan<- *prometheus.Desc) {
+	for _, collector := range m.collectors() {
+		collector.Describe(descs)
+	}
+}
+
+// Collect implements prometheus.Collector.
+func (m *Metrics) Collect(metrics chan<- prometheus.Metric) {
+	for _, collector := range m.collectors() {
+		collector.Collect(metrics)
+	}
+}
+
+func (m *Metrics) collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		m.requestDuration,
+		m.requestErrors,
+		m.requestsInFlight,
+		m.bytesWritten,
+		m.bytesRead,
+		m.reconnects,
+		m.reconnectErrors,
+		m.droppedNotifications,
+	}
+}
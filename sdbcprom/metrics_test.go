@@ -0,0 +1,89 @@
+package sdbcprom
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"gotest.tools/v3/assert"
+)
+
+func TestMetrics(t *testing.T) {
+	t.Parallel()
+
+	metrics := NewMetrics(
+		WithNamespace("test"),
+		WithBuckets([]float64{0.01, 0.1}),
+		WithConstLabels(prometheus.Labels{"client": "main"}),
+	)
+
+	registry := prometheus.NewPedanticRegistry()
+	assert.NilError(t, registry.Register(metrics))
+
+	metrics.ObserveRequest("query", 5*time.Millisecond, nil)
+	metrics.ObserveRequest("query", 50*time.Millisecond, errors.New("test error"))
+	metrics.ObserveRequest("select", time.Second, nil)
+	metrics.SetRequestsInFlight(3)
+	metrics.AddBytesWritten(100)
+	metrics.AddBytesWritten(20)
+	metrics.AddBytesRead(42)
+	metrics.IncReconnects(nil)
+	metrics.IncReconnects(errors.New("test error"))
+	metrics.IncDroppedNotifications()
+
+	expected := `
+		# HELP test_live_notifications_dropped_total Number of live query notifications that could not be delivered.
+		# TYPE test_live_notifications_dropped_total counter
+		test_live_notifications_dropped_total{client="main"} 1
+		# HELP test_request_duration_seconds Duration of RPC requests from sending until the response has been received.
+		# TYPE test_request_duration_seconds histogram
+		test_request_duration_seconds_bucket{client="main",method="query",le="0.01"} 1
+		test_request_duration_seconds_bucket{client="main",method="query",le="0.1"} 2
+		test_request_duration_seconds_bucket{client="main",method="query",le="+Inf"} 2
+		test_request_duration_seconds_sum{client="main",method="query"} 0.055
+		test_request_duration_seconds_count{client="main",method="query"} 2
+		test_request_duration_seconds_bucket{client="main",method="select",le="0.01"} 0
+		test_request_duration_seconds_bucket{client="main",method="select",le="0.1"} 0
+		test_request_duration_seconds_bucket{client="main",method="select",le="+Inf"} 1
+		test_request_duration_seconds_sum{client="main",method="select"} 1
+		test_request_duration_seconds_count{client="main",method="select"} 1
+		# HELP test_request_errors_total Number of failed RPC requests.
+		# TYPE test_request_errors_total counter
+		test_request_errors_total{client="main",method="query"} 1
+		# HELP test_requests_in_flight Number of RPC requests waiting for a response.
+		# TYPE test_requests_in_flight gauge
+		test_requests_in_flight{client="main"} 3
+		# HELP test_websocket_read_bytes_total Number of bytes read from the websocket.
+		# TYPE test_websocket_read_bytes_total counter
+		test_websocket_read_bytes_total{client="main"} 42
+		# HELP test_websocket_reconnect_errors_total Number of failed websocket reconnects.
+		# TYPE test_websocket_reconnect_errors_total counter
+		test_websocket_reconnect_errors_total{client="main"} 1
+		# HELP test_websocket_reconnects_total Number of attempted websocket reconnects.
+		# TYPE test_websocket_reconnects_total counter
+		test_websocket_reconnects_total{client="main"} 2
+		# HELP test_websocket_written_bytes_total Number of bytes written to the websocket.
+		# TYPE test_websocket_written_bytes_total counter
+		test_websocket_written_bytes_total{client="main"} 120
+	`
+
+	assert.NilError(t, testutil.GatherAndCompare(registry, strings.NewReader(expected)))
+}
+
+func TestMetricsDefaults(t *testing.T) {
+	t.Parallel()
+
+	metrics := NewMetrics()
+
+	registry := prometheus.NewRegistry()
+	assert.NilError(t, registry.Register(metrics))
+
+	metrics.ObserveRequest("query", time.Millisecond, nil)
+
+	count, err := testutil.GatherAndCount(registry, "sdbc_request_duration_seconds")
+	assert.NilError(t, err)
+	assert.Equal(t, 1, count)
+}
@@ -2,9 +2,12 @@ package sdbc
 
 import (
 	"bytes"
+	"context"
 	cryptorand "crypto/rand"
 	"encoding/binary"
+	"maps"
 	"math/rand/v2"
+	"slices"
 	"sync"
 )
 
@@ -57,7 +60,8 @@ func (p *bufPool) put(buf *bytes.Buffer) {
 
 func newRequests() *requests {
 	return &requests{
-		store: map[string]chan *output{},
+		store:    map[string]chan *output{},
+		redacted: map[string]struct{}{},
 	}
 }
 
@@ -67,8 +71,9 @@ type output struct {
 }
 
 type requests struct {
-	mut   sync.RWMutex
-	store map[string]chan *output
+	mut      sync.RWMutex
+	store    map[string]chan *output
+	redacted map[string]struct{} // requests whose response must not be logged
 }
 
 func (r *requests) prepare() (string, <-chan *output) {
@@ -103,6 +108,27 @@ func (r *requests) del(key string) {
 		close(outChan)
 		delete(r.store, key)
 	}
+
+	delete(r.redacted, key)
+}
+
+// redact marks the response of the request with the given key as not to be logged.
+func (r *requests) redact(key string) {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	r.redacted[key] = struct{}{}
+}
+
+// isRedacted reports whether the response of the
+// request with the given key must not be logged.
+func (r *requests) isRedacted(key string) bool {
+	r.mut.RLock()
+	defer r.mut.RUnlock()
+
+	_, ok := r.redacted[key]
+
+	return ok
 }
 
 func (r *requests) reset() {
@@ -113,6 +139,7 @@ func (r *requests) reset() {
 		close(outChan)
 	}
 	r.store = map[string]chan *output{}
+	r.redacted = map[string]struct{}{}
 }
 
 func (r *requests) len() int {
@@ -128,13 +155,15 @@ func (r *requests) len() int {
 
 func newLiveQueries() *liveQueries {
 	return &liveQueries{
-		store: map[string]chan []byte{},
+		store:    map[string]chan []byte{},
+		contexts: map[string]context.Context{},
 	}
 }
 
 type liveQueries struct {
-	mut   sync.RWMutex
-	store map[string]chan []byte
+	mut      sync.RWMutex
+	store    map[string]chan []byte
+	contexts map[string]context.Context // contexts the live queries were started with
 }
 
 func (l *liveQueries) get(key string, create bool) (chan []byte, bool) {
@@ -167,6 +196,25 @@ func (l *liveQueries) del(key string) {
 		close(liveChan)
 		delete(l.store, key)
 	}
+
+	delete(l.contexts, key)
+}
+
+// setContext stores the context the live query with the given key was started with.
+func (l *liveQueries) setContext(key string, ctx context.Context) {
+	l.mut.Lock()
+	defer l.mut.Unlock()
+
+	l.contexts[key] = ctx
+}
+
+// context returns the context the live query with the given
+// key was started with, or nil if there is none.
+func (l *liveQueries) context(key string) context.Context {
+	l.mut.RLock()
+	defer l.mut.RUnlock()
+
+	return l.contexts[key]
 }
 
 func (l *liveQueries) reset() {
@@ -177,6 +225,69 @@ func (l *liveQueries) reset() {
 		close(outChan)
 	}
 	l.store = map[string]chan []byte{}
+	l.contexts = map[string]context.Context{}
+}
+
+//
+// -- LIVE PARAMS
+//
+
+func newLiveParams() *liveParams {
+	return &liveParams{
+		store: map[string]struct{}{},
+	}
+}
+
+// liveParams tracks the database params defined by this client for live queries,
+// so that they can be removed even if the live query is not killed regularly.
+type liveParams struct {
+	mut   sync.Mutex
+	store map[string]struct{}
+}
+
+func (p *liveParams) add(names ...string) {
+	p.mut.Lock()
+	defer p.mut.Unlock()
+
+	for _, name := range names {
+		p.store[name] = struct{}{}
+	}
+}
+
+// take removes the given names from the store and returns those that were
+// present, so that each param is removed from the database only once.
+func (p *liveParams) take(names ...string) []string {
+	p.mut.Lock()
+	defer p.mut.Unlock()
+
+	taken := make([]string, 0, len(names))
+
+	for _, name := range names {
+		if _, ok := p.store[name]; ok {
+			delete(p.store, name)
+			taken = append(taken, name)
+		}
+	}
+
+	return taken
+}
+
+// takeAll removes all names from the store and returns them sorted.
+func (p *liveParams) takeAll() []string {
+	p.mut.Lock()
+	defer p.mut.Unlock()
+
+	taken := slices.Sorted(maps.Keys(p.store))
+	p.store = map[string]struct{}{}
+
+	return taken
+}
+
+func (p *liveParams) len() int {
+	p.mut.Lock()
+	defer p.mut.Unlock()
+
+	return len(p.store)
 }
 
 //
@@ -77,6 +77,22 @@ func TestLiveQueriesDel(t *testing.T) {
 	}
 }
 
+func TestLiveParams(t *testing.T) {
+	t.Parallel()
+
+	var lp = newLiveParams()
+
+	lp.add("b", "a", "c")
+	assert.Equal(t, 3, lp.len())
+
+	assert.DeepEqual(t, []string{"a"}, lp.take("a", "unknown"))
+	assert.DeepEqual(t, []string{}, lp.take("a"))
+	assert.Equal(t, 2, lp.len())
+
+	assert.DeepEqual(t, []string{"b", "c"}, lp.takeAll())
+	assert.Equal(t, 0, lp.len())
+}
+
 func TestNewRandBytes(t *testing.T) {
 	t.Parallel()
 	// Basic test to ensure that newRandBytes doesn't panic.
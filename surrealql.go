@@ -0,0 +1,775 @@
+package sdbc
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/go-surreal/sdbc/internal/surrealql"
+)
+
+var ErrInvalidRecordID = errors.New("invalid record id")
+
+//
+// -- ESCAPING
+//
+
+// escapeFieldPath escapes each part of a (dot separated) field path, e.g. stats.count.
+func escapeFieldPath(path string) string {
+	parts := strings.Split(path, ".")
+
+	for index, part := range parts {
+		parts[index] = surrealql.EscapeIdent(part)
+	}
+
+	return strings.Join(parts, ".")
+}
+
+// escapeRecordPart escapes the table or a string identifier of a record ID.
+// Parts that are not plain identifiers are wrapped in angle brackets (⟨⟩).
+func escapeRecordPart(part string) string {
+	if surrealql.IsIdent(part) {
+		return part
+	}
+
+	return "⟨" + strings.ReplaceAll(strings.ReplaceAll(part, `\`, `\\`), "⟩", `\⟩`) + "⟩"
+}
+
+// escapeKey escapes the key of an object for the use within SurrealQL.
+func escapeKey(key string) string {
+	if surrealql.IsIdent(key) {
+		return key
+	}
+
+	return quoteString(key, '"')
+}
+
+// quoteString wraps the given string in the given quote
+// character and escapes all characters where necessary.
+func quoteString(str string, quote byte) string {
+	var builder strings.Builder
+
+	builder.Grow(len(str) + 2) //nolint:mnd // two quotes
+	builder.WriteByte(quote)
+
+	for _, char := range str {
+		switch char {
+
+		case rune(quote), '\\':
+			builder.WriteByte('\\')
+			builder.WriteRune(char)
+
+		case '\n':
+			builder.WriteString(`\n`)
+
+		case '\r':
+			builder.WriteString(`\r`)
+
+		case '\t':
+			builder.WriteString(`\t`)
+
+		default:
+			builder.WriteRune(char)
+		}
+	}
+
+	builder.WriteByte(quote)
+
+	return builder.String()
+}
+
+//
+// -- FORMATTING
+//
+
+// formatValue writes the SurrealQL representation of the given value.
+// Object keys are sorted, so the output is deterministic.
+func formatValue(builder *strings.Builder, val any) {
+	switch typed := val.(type) {
+
+	case nil:
+		builder.WriteString("NULL")
+
+	case None, *None:
+		builder.WriteString("NONE")
+
+	case bool:
+		builder.WriteString(strconv.FormatBool(typed))
+
+	case string:
+		builder.WriteString(quoteString(typed, '\''))
+
+	case int:
+		builder.WriteString(strconv.Itoa(typed))
+
+	case int64:
+		builder.WriteString(strconv.FormatInt(typed, 10))
+
+	case uint64:
+		builder.WriteString(strconv.FormatUint(typed, 10))
+
+	case float32:
+		formatFloat(builder, float64(typed))
+
+	case float64:
+		formatFloat(builder, typed)
+
+	case Decimal:
+		builder.WriteString(strconv.FormatFloat(typed.float64, 'f', -1, 64) + "dec")
+
+	case DateTime:
+		builder.WriteString("d" + quoteString(typed.UTC().Format(time.RFC3339Nano), '\''))
+
+	case time.Time:
+		builder.WriteString("d" + quoteString(typed.UTC().Format(time.RFC3339Nano), '\''))
+
+	case Duration:
+		builder.WriteString(surrealql.FormatDuration(typed.Duration))
+
+	case UUID:
+		builder.WriteString("u" + quoteString(typed.String(), '\''))
+
+	case Table:
+		builder.WriteString(typed.String())
+
+	case *ID:
+		builder.WriteString(typed.String())
+
+	case Range:
+		builder.WriteString(typed.String())
+
+	case File:
+		builder.WriteString(typed.String())
+
+	case Future:
+		builder.WriteString("<future> " + string(typed))
+
+	case []any:
+		builder.WriteByte('[')
+
+		for index, item := range typed {
+			if index > 0 {
+				builder.WriteString(", ")
+			}
+
+			formatValue(builder, item)
+		}
+
+		builder.WriteByte(']')
+
+	case map[string]any:
+		if len(typed) == 0 {
+			builder.WriteString("{}")
+
+			return
+		}
+
+		keys := make([]string, 0, len(typed))
+		for key := range typed {
+			keys = append(keys, key)
+		}
+
+		slices.Sort(keys)
+
+		builder.WriteString("{ ")
+
+		for index, key := range keys {
+			if index > 0 {
+				builder.WriteString(", ")
+			}
+
+			builder.WriteString(escapeKey(key))
+			builder.WriteString(": ")
+			formatValue(builder, typed[key])
+		}
+
+		builder.WriteString(" }")
+
+	default:
+		builder.WriteString(quoteString(fmt.Sprint(typed), '\''))
+	}
+}
+
+// formatIdentifier writes the identifier part of a record ID. In contrast to
+// other values, string identifiers are escaped instead of quoted.
+func formatIdentifier(builder *strings.Builder, key any) {
+	switch typed := key.(type) {
+
+	case string:
+		builder.WriteString(escapeRecordPart(typed))
+
+	case Range:
+		typed.format(builder, formatIdentifier)
+
+	default:
+		formatValue(builder, typed)
+	}
+}
+
+func formatFloat(builder *strings.Builder, val float64) {
+	switch {
+
+	case math.IsNaN(val):
+		builder.WriteString("NaN")
+
+	case math.IsInf(val, 1):
+		builder.WriteString("Infinity")
+
+	case math.IsInf(val, -1):
+		builder.WriteString("-Infinity")
+
+	default:
+		builder.WriteString(strconv.FormatFloat(val, 'f', -1, 64) + "f")
+	}
+}
+
+//
+// -- PARSING
+//
+
+// ParseID parses a record ID in the SurrealQL format. Supported are plain
+// (person:tobie), escaped (person:⟨tobie hitchcock⟩ or person:`x`), integer
+// (person:100), UUID (person:u'...'), array (t:['London', d'2024-01-01'])
+// and object (t:{ city: 'London' }) identifiers as well as ranges of those
+// (person:1..10, person:1>..=10, person:..10 or t:['London']..).
+func ParseID(str string) (*ID, error) {
+	parser := &idParser{input: str}
+
+	id, err := parser.parseRecord()
+	if err != nil {
+		return nil, err
+	}
+
+	parser.skipSpace()
+
+	if !parser.done() {
+		return nil, parser.errorf("unexpected trailing input")
+	}
+
+	return id, nil
+}
+
+type idParser struct {
+	input string
+	pos   int
+}
+
+func (p *idParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("%w %q at position %d: %s", ErrInvalidRecordID, p.input, p.pos, fmt.Sprintf(format, args...))
+}
+
+func (p *idParser) done() bool {
+	return p.pos >= len(p.input)
+}
+
+func (p *idParser) peek() rune {
+	if p.done() {
+		return 0
+	}
+
+	char, _ := utf8.DecodeRuneInString(p.input[p.pos:])
+
+	return char
+}
+
+func (p *idParser) next() rune {
+	char, size := utf8.DecodeRuneInString(p.input[p.pos:])
+	p.pos += size
+
+	return char
+}
+
+func (p *idParser) consume(char rune) bool {
+	if !p.done() && p.peek() == char {
+		p.next()
+
+		return true
+	}
+
+	return false
+}
+
+func (p *idParser) skipSpace() {
+	for !p.done() && unicode.IsSpace(p.peek()) {
+		p.next()
+	}
+}
+
+func (p *idParser) parseIdentChars() string {
+	start := p.pos
+
+	for !p.done() && surrealql.IsIdentChar(p.peek()) {
+		p.next()
+	}
+
+	return p.input[start:p.pos]
+}
+
+// parseRecord parses a complete record ID (table:identifier).
+func (p *idParser) parseRecord() (*ID, error) {
+	table, err := p.parseTable()
+	if err != nil {
+		return nil, err
+	}
+
+	if !p.consume(':') {
+		return nil, p.errorf("expected ':' after table")
+	}
+
+	identifier, err := p.parseRange()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ID{table: table, identifier: identifier}, nil
+}
+
+// parseRange parses an identifier that is optionally followed by a range
+// operator (.., >.., ..= or >..=). If it is a range, a Range is returned.
+func (p *idParser) parseRange() (any, error) {
+	var rng Range
+
+	if !strings.HasPrefix(p.input[p.pos:], "..") {
+		begin, err := p.parseIdentifier()
+		if err != nil {
+			return nil, err
+		}
+
+		if !p.isRangeOperator() {
+			return begin, nil
+		}
+
+		rng.Begin = Included(begin)
+
+		if p.consume('>') {
+			rng.Begin.Kind = BoundExcluded
+		}
+	}
+
+	p.pos += len("..")
+
+	if p.done() {
+		return rng, nil
+	}
+
+	included := p.consume('=')
+
+	end, err := p.parseIdentifier()
+	if err != nil {
+		return nil, err
+	}
+
+	rng.End = Excluded(end)
+
+	if included {
+		rng.End.Kind = BoundIncluded
+	}
+
+	return rng, nil
+}
+
+func (p *idParser) isRangeOperator() bool {
+	rest := p.input[p.pos:]
+
+	return strings.HasPrefix(rest, "..") || strings.HasPrefix(rest, ">..")
+}
+
+func (p *idParser) parseTable() (string, error) {
+	switch p.peek() {
+
+	case '⟨':
+		return p.parseEscaped('⟨', '⟩')
+
+	case '`':
+		return p.parseEscaped('`', '`')
+	}
+
+	table := p.parseIdentChars()
+	if table == "" {
+		return "", p.errorf("expected table name")
+	}
+
+	return table, nil
+}
+
+func (p *idParser) parseIdentifier() (any, error) {
+	switch char := p.peek(); {
+
+	case char == '⟨':
+		return p.parseEscaped('⟨', '⟩')
+
+	case char == '`':
+		return p.parseEscaped('`', '`')
+
+	case char == '[':
+		return p.parseArray()
+
+	case char == '{':
+		return p.parseObject()
+
+	case char == 'u' && p.isPrefixedString():
+		return p.parseValue()
+
+	case char == '-':
+		p.next()
+
+		digits := p.parseIdentChars()
+
+		num, err := strconv.ParseInt("-"+digits, 10, 64)
+		if err != nil {
+			return nil, p.errorf("invalid integer identifier")
+		}
+
+		return num, nil
+	}
+
+	key := p.parseIdentChars()
+	if key == "" {
+		return nil, p.errorf("expected identifier")
+	}
+
+	if strings.Trim(key, "0123456789") == "" {
+		num, err := strconv.ParseInt(key, 10, 64)
+		if err != nil {
+			return nil, p.errorf("integer identifier out of range")
+		}
+
+		return num, nil
+	}
+
+	return key, nil
+}
+
+// parseEscaped parses a string enclosed by the given characters.
+// Within the string, the closing character and the backslash can
+// be escaped by a preceding backslash.
+func (p *idParser) parseEscaped(open, closing rune) (string, error) {
+	if !p.consume(open) {
+		return "", p.errorf("expected %q", open)
+	}
+
+	var builder strings.Builder
+
+	for !p.done() {
+		char := p.next()
+
+		switch char {
+
+		case closing:
+			return builder.String(), nil
+
+		case '\\':
+			if p.done() {
+				return "", p.errorf("unterminated escape sequence")
+			}
+
+			builder.WriteRune(p.next())
+
+		default:
+			builder.WriteRune(char)
+		}
+	}
+
+	return "", p.errorf("expected %q", closing)
+}
+
+func (p *idParser) isPrefixedString() bool {
+	rest := p.input[p.pos:]
+
+	return len(rest) > 1 && strings.ContainsRune("dursb", rune(rest[0])) && (rest[1] == '\'' || rest[1] == '"')
+}
+
+func (p *idParser) parseValue() (any, error) {
+	p.skipSpace()
+
+	switch char := p.peek(); {
+
+	case p.done():
+		return nil, p.errorf("unexpected end of input")
+
+	case char == '[':
+		return p.parseArray()
+
+	case char == '{':
+		return p.parseObject()
+
+	case char == '\'' || char == '"':
+		return p.parseString()
+
+	case p.isPrefixedString():
+		return p.parsePrefixedString()
+
+	case char == '-' || (char >= '0' && char <= '9'):
+		return p.parseNumber()
+
+	case char == '⟨' || char == '`':
+		return p.parseRecord()
+
+	case surrealql.IsIdentChar(char):
+		start := p.pos
+		word := p.parseIdentChars()
+
+		if p.peek() == ':' {
+			p.pos = start
+
+			return p.parseRecord()
+		}
+
+		switch strings.ToUpper(word) {
+
+		case "TRUE":
+			return true, nil
+
+		case "FALSE":
+			return false, nil
+
+		case "NULL":
+			return nil, nil //nolint:nilnil // null is a valid value
+
+		case "NONE":
+			return None{}, nil
+		}
+
+		p.pos = start
+
+		return nil, p.errorf("unexpected identifier %q", word)
+
+	default:
+		return nil, p.errorf("unexpected character %q", char)
+	}
+}
+
+func (p *idParser) parseArray() ([]any, error) {
+	if !p.consume('[') {
+		return nil, p.errorf("expected '['")
+	}
+
+	items := []any{}
+
+	for {
+		p.skipSpace()
+
+		if p.consume(']') {
+			return items, nil
+		}
+
+		item, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, item)
+
+		p.skipSpace()
+
+		if p.consume(']') {
+			return items, nil
+		}
+
+		if !p.consume(',') {
+			return nil, p.errorf("expected ',' or ']'")
+		}
+	}
+}
+
+func (p *idParser) parseObject() (map[string]any, error) {
+	if !p.consume('{') {
+		return nil, p.errorf("expected '{'")
+	}
+
+	object := map[string]any{}
+
+	for {
+		p.skipSpace()
+
+		if p.consume('}') {
+			return object, nil
+		}
+
+		var key string
+
+		if char := p.peek(); char == '\'' || char == '"' {
+			str, err := p.parseString()
+			if err != nil {
+				return nil, err
+			}
+
+			key = str
+		} else {
+			key = p.parseIdentChars()
+			if key == "" {
+				return nil, p.errorf("expected object key")
+			}
+		}
+
+		p.skipSpace()
+
+		if !p.consume(':') {
+			return nil, p.errorf("expected ':' after object key")
+		}
+
+		val, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+
+		object[key] = val
+
+		p.skipSpace()
+
+		if p.consume('}') {
+			return object, nil
+		}
+
+		if !p.consume(',') {
+			return nil, p.errorf("expected ',' or '}'")
+		}
+	}
+}
+
+func (p *idParser) parseString() (string, error) {
+	quote := p.peek()
+	if quote != '\'' && quote != '"' {
+		return "", p.errorf("expected string")
+	}
+
+	p.next()
+
+	var builder strings.Builder
+
+	for !p.done() {
+		char := p.next()
+
+		switch char {
+
+		case quote:
+			return builder.String(), nil
+
+		case '\\':
+			if p.done() {
+				return "", p.errorf("unterminated escape sequence")
+			}
+
+			switch escaped := p.next(); escaped {
+
+			case 'n':
+				builder.WriteByte('\n')
+
+			case 'r':
+				builder.WriteByte('\r')
+
+			case 't':
+				builder.WriteByte('\t')
+
+			case '0':
+				builder.WriteByte(0)
+
+			default:
+				builder.WriteRune(escaped)
+			}
+
+		default:
+			builder.WriteRune(char)
+		}
+	}
+
+	return "", p.errorf("unterminated string")
+}
+
+var datetimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02",
+}
+
+func (p *idParser) parsePrefixedString() (any, error) {
+	prefix := p.next()
+
+	str, err := p.parseString()
+	if err != nil {
+		return nil, err
+	}
+
+	switch prefix {
+
+	case 'd':
+		for _, layout := range datetimeLayouts {
+			if parsed, err := time.Parse(layout, str); err == nil {
+				return DateTime{Time: parsed}, nil
+			}
+		}
+
+		return nil, p.errorf("invalid datetime %q", str)
+
+	case 'u':
+		uuid, err := ParseUUID(str)
+		if err != nil {
+			return nil, p.errorf("invalid uuid %q", str)
+		}
+
+		return uuid, nil
+
+	case 'r':
+		id, err := ParseID(str)
+		if err != nil {
+			return nil, p.errorf("invalid record %q", str)
+		}
+
+		return id, nil
+
+	default:
+		return str, nil
+	}
+}
+
+func (p *idParser) parseNumber() (any, error) {
+	start := p.pos
+
+	p.consume('-')
+
+	for !p.done() && (surrealql.IsIdentChar(p.peek()) || p.peek() == 'µ' || p.peek() == '.' ||
+		((p.peek() == '+' || p.peek() == '-') && strings.ContainsRune("eE", rune(p.input[p.pos-1])))) {
+		p.next()
+	}
+
+	literal := p.input[start:p.pos]
+
+	switch {
+
+	case strings.HasSuffix(literal, "dec"):
+		num, err := strconv.ParseFloat(strings.TrimSuffix(literal, "dec"), 64)
+		if err != nil {
+			return nil, p.errorf("invalid decimal %q", literal)
+		}
+
+		return MakeDecimal(num), nil
+
+	case strings.HasSuffix(literal, "f"):
+		num, err := strconv.ParseFloat(strings.TrimSuffix(literal, "f"), 64)
+		if err != nil {
+			return nil, p.errorf("invalid float %q", literal)
+		}
+
+		return num, nil
+	}
+
+	if num, err := strconv.ParseInt(literal, 10, 64); err == nil {
+		return num, nil
+	}
+
+	if num, err := strconv.ParseFloat(literal, 64); err == nil {
+		return num, nil
+	}
+
+	if dur, err := parseDuration(literal); err == nil {
+		return Duration{dur}, nil
+	}
+
+	return nil, p.errorf("invalid number %q", literal)
+}
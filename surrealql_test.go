@@ -0,0 +1,160 @@
+package sdbc
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/assert/cmp"
+)
+
+func TestParseID(t *testing.T) {
+	t.Parallel()
+
+	date := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	uuid, err := ParseUUID("0189a3b2-5c1e-7d6f-8a9b-0c1d2e3f4a5b")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		in       string
+		expected *ID
+	}{
+		{in: "person:tobie", expected: StringID("person", "tobie")},
+		{in: "person:⟨john doe⟩", expected: StringID("person", "john doe")},
+		{in: "person:`x`", expected: StringID("person", "x")},
+		{in: `person:⟨a\⟩b⟩`, expected: StringID("person", "a⟩b")},
+		{in: "person:⟨100⟩", expected: StringID("person", "100")},
+		{in: "person:100", expected: IntID("person", 100)},
+		{in: "person:-7", expected: IntID("person", -7)},
+		{in: "person:100abc", expected: StringID("person", "100abc")},
+		{in: "⟨some table⟩:x", expected: StringID("some table", "x")},
+		{in: "person:u'0189a3b2-5c1e-7d6f-8a9b-0c1d2e3f4a5b'", expected: UUIDID("person", uuid)},
+		{in: "t:[1,2]", expected: ArrayID("t", 1, 2)},
+		{in: "t:[]", expected: ArrayID("t")},
+		{
+			in:       "temperature:['London', d'2024-01-01']",
+			expected: ArrayID("temperature", "London", DateTime{date}),
+		},
+		{
+			in:       `t:[1.5f, 2.5, 3dec, "it's", true, NONE, null, 1h30m, [person:tobie], r'a:b',]`,
+			expected: ArrayID("t", 1.5, 2.5, MakeDecimal(3), "it's", true, None{}, nil, Duration{90 * time.Minute}, []any{StringID("person", "tobie")}, StringID("a", "b")),
+		},
+		{in: "t:{a:1}", expected: ObjectID("t", map[string]any{"a": 1})},
+		{
+			in:       `t:{ city: 'London', "some key": { nested: [u'0189a3b2-5c1e-7d6f-8a9b-0c1d2e3f4a5b'] } }`,
+			expected: ObjectID("t", map[string]any{"city": "London", "some key": map[string]any{"nested": []any{uuid}}}),
+		},
+		{in: "person:1..10", expected: RangeID("person", Range{Begin: Included(1), End: Excluded(10)})},
+		{in: "person:1>..=10", expected: RangeID("person", Range{Begin: Excluded(1), End: Included(10)})},
+		{in: "person:..=10", expected: RangeID("person", Range{End: Included(10)})},
+		{in: "person:a..", expected: RangeID("person", Range{Begin: Included("a")})},
+		{in: "person:..", expected: RangeID("person", Range{})},
+		{in: "person:⟨a b⟩..z", expected: RangeID("person", Range{Begin: Included("a b"), End: Excluded("z")})},
+		{
+			in:       "temperature:['London']..=['London', NONE]",
+			expected: RangeID("temperature", Range{Begin: Included([]any{"London"}), End: Included([]any{"London", None{}})}),
+		},
+	}
+
+	for _, test := range tests {
+		id, err := ParseID(test.in)
+		if err != nil {
+			t.Fatalf("%s: %v", test.in, err)
+		}
+
+		assert.Check(t, test.expected.Equal(id), "%s: expected %s, got %s", test.in, test.expected, id)
+
+		// the formatted output must be parsable as well
+		reparsed, err := ParseID(id.String())
+		if err != nil {
+			t.Fatalf("%s: %v", id.String(), err)
+		}
+
+		assert.Check(t, id.Equal(reparsed), "%s != %s", id, reparsed)
+	}
+}
+
+func TestParseIDErrors(t *testing.T) {
+	t.Parallel()
+
+	invalid := []string{
+		"",
+		"person",
+		":tobie",
+		"person:",
+		"a:b:c",
+		"person:⟨tobie",
+		"person:tobie doe",
+		"t:[1,2",
+		"t:{a 1}",
+		"t:{a:1,,}",
+		"t:[d'invalid']",
+		"t:[u'invalid']",
+		"t:[foo]",
+		"person:99999999999999999999",
+		"person:>..1",
+		"person:1..=",
+		"person:1...2",
+		"person:1>",
+	}
+
+	for _, in := range invalid {
+		_, err := ParseID(in)
+		assert.Check(t, errors.Is(err, ErrInvalidRecordID), "%q: %v", in, err)
+
+		_, ok := ParseRecord(in)
+		assert.Check(t, !ok, in)
+	}
+
+	for _, in := range []string{"t:[1,", "t:{a:", "t:[1, {a: ["} {
+		_, err := ParseID(in)
+		assert.Check(t, cmp.ErrorContains(err, "unexpected end of input"), in)
+	}
+}
+
+func TestIDText(t *testing.T) {
+	t.Parallel()
+
+	type document struct {
+		Record *ID `json:"record"`
+	}
+
+	in := document{Record: ArrayID("temperature", "London", 42)}
+
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, `{"record":"temperature:['London', 42]"}`, string(data))
+
+	var out document
+
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Check(t, in.Record.Equal(out.Record))
+
+	err = json.Unmarshal([]byte(`{"record":"invalid"}`), &out)
+	assert.Check(t, errors.Is(err, ErrInvalidRecordID))
+
+	var nilID *ID
+
+	text, err := nilID.MarshalText()
+	assert.NilError(t, err)
+	assert.Equal(t, "", string(text))
+}
+
+func TestEscapeFieldPath(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "name", escapeFieldPath("name"))
+	assert.Equal(t, "stats.count", escapeFieldPath("stats.count"))
+	assert.Equal(t, "stats.`some count`", escapeFieldPath("stats.some count"))
+}
@@ -0,0 +1,142 @@
+package sdbc
+
+import (
+	"context"
+	"time"
+)
+
+// Tracer instruments the requests and live query notifications of the
+// client, e.g. to create spans for distributed tracing. It is set with
+// WithTracer. See package sdbcotel for an OpenTelemetry implementation.
+type Tracer interface {
+	// StartRequest is called before a request is sent. The returned context
+	// is used for the request and the returned function is called once the
+	// response has been received (or the request failed).
+	StartRequest(ctx context.Context, info RequestInfo) (context.Context, func(info ResponseInfo))
+
+	// StartNotification is called when a notification of a live query is
+	// received. The context is the one the live query was started with, so
+	// that notifications can be related to it. The returned function is
+	// called once the notification has been delivered to the channel or
+	// dropped, in which case the error wraps ErrLiveNotificationDropped.
+	StartNotification(ctx context.Context, info NotificationInfo) func(err error)
+}
+
+// RequestInfo describes a request passed to Tracer.StartRequest.
+type RequestInfo struct {
+	// Method is the RPC method, e.g. query or select.
+	Method string
+
+	Namespace string
+	Database  string
+
+	// Statements is the number of statements of a query request.
+	// It is 0 for other methods or if the query could not be parsed.
+	Statements int
+}
+
+// ResponseInfo describes the response of a request passed to the
+// function returned by Tracer.StartRequest.
+type ResponseInfo struct {
+	// Size is the size of the result in bytes.
+	Size int
+
+	// ServerTime is the execution time reported by the server, summed
+	// up for all statements of a query request. It is 0 for other methods.
+	ServerTime time.Duration
+
+	// Err is the error of the request. For query requests, it is also set
+	// if a statement failed (wrapping ErrResponseNotOkay), even though the
+	// request itself succeeded.
+	Err error
+}
+
+// NotificationInfo describes a live query notification
+// passed to Tracer.StartNotification.
+type NotificationInfo struct {
+	// LiveID is the ID of the live query.
+	LiveID string
+
+	// Action is the action of the notification, e.g. CREATE.
+	Action string
+
+	// Size is the size of the notification in bytes.
+	Size int
+}
+
+// liveNotification is the part of a live query notification
+// that is decoded for tracing.
+type liveNotification struct {
+	Action string `json:"action"`
+}
+
+// traceRequest sends the request and reports it to the tracer.
+func (c *Client) traceRequest(ctx context.Context, req request) ([]byte, error) {
+	ctx, end := c.tracer.StartRequest(ctx, c.requestInfo(req))
+
+	res, err := c.roundTrip(ctx, req)
+
+	end(c.responseInfo(req, res, err))
+
+	return res, err
+}
+
+func (c *Client) requestInfo(req request) RequestInfo {
+	info := RequestInfo{
+		Method:    req.Method,
+		Namespace: c.conf.Namespace,
+		Database:  c.conf.Database,
+	}
+
+	if req.Method != methodQuery || len(req.Params) == 0 {
+		return info
+	}
+
+	if query, ok := req.Params[0].(string); ok {
+		if tokens, err := lex(query); err == nil {
+			info.Statements = countStatements(tokens)
+		}
+	}
+
+	return info
+}
+
+func (c *Client) responseInfo(req request, res []byte, err error) ResponseInfo {
+	info := ResponseInfo{Size: len(res), Err: err}
+
+	if err != nil || req.Method != methodQuery {
+		return info
+	}
+
+	summary, ok := c.parseQueryResponse(res)
+	if !ok {
+		return info
+	}
+
+	for _, statementTime := range summary.times {
+		info.ServerTime += statementTime
+	}
+
+	info.Err = summary.err
+
+	return info
+}
+
+// startNotification reports the notification of the live query with the
+// given ID to the tracer. The returned function must be called once the
+// notification has been delivered or dropped.
+func (c *Client) startNotification(ctx context.Context, liveID string, res *response) func(err error) {
+	if c.tracer == nil {
+		return func(error) {}
+	}
+
+	var notification liveNotification
+
+	_ = c.unmarshal(res.Result, &notification) // the action is for information only
+
+	return c.tracer.StartNotification(ctx, NotificationInfo{
+		LiveID: liveID,
+		Action: notification.Action,
+		Size:   len(res.Result),
+	})
+}
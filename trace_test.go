@@ -0,0 +1,136 @@
+package sdbc
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestTracerRequestInfo(t *testing.T) {
+	t.Parallel()
+
+	marshal, unmarshal := newTestCodec(t, nil)
+
+	client := &Client{
+		options:   applyOptions(nil),
+		marshal:   marshal,
+		unmarshal: unmarshal,
+		conf:      Config{Namespace: "ns", Database: "db"},
+	}
+
+	info := client.requestInfo(request{Method: methodQuery, Params: []any{"SELECT * FROM a; SELECT * FROM b;", nil}})
+	assert.DeepEqual(t, RequestInfo{Method: methodQuery, Namespace: "ns", Database: "db", Statements: 2}, info)
+
+	info = client.requestInfo(request{Method: methodQuery, Params: []any{"SELECT 'unterminated"}})
+	assert.Equal(t, 0, info.Statements)
+
+	info = client.requestInfo(request{Method: methodSelect, Params: []any{"user"}})
+	assert.Equal(t, 0, info.Statements)
+
+	res, err := marshal([]basicResponse[any]{
+		{Status: "OK", Result: 1, Time: duration(time.Millisecond)},
+		{Status: "ERR", Result: "Parse error", Time: duration(2 * time.Millisecond)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resInfo := client.responseInfo(request{Method: methodQuery}, res, nil)
+	assert.Equal(t, len(res), resInfo.Size)
+	assert.Equal(t, 3*time.Millisecond, resInfo.ServerTime)
+	assert.Check(t, errors.Is(resInfo.Err, ErrResponseNotOkay))
+	assert.ErrorContains(t, resInfo.Err, "Parse error")
+
+	resInfo = client.responseInfo(request{Method: methodSelect}, res, nil)
+	assert.Equal(t, time.Duration(0), resInfo.ServerTime)
+	assert.NilError(t, resInfo.Err)
+
+	resInfo = client.responseInfo(request{Method: methodQuery}, nil, ErrChannelClosed)
+	assert.Check(t, errors.Is(resInfo.Err, ErrChannelClosed))
+}
+
+func TestTracerNotification(t *testing.T) {
+	t.Parallel()
+
+	marshal, unmarshal := newTestCodec(t, nil)
+	tracer := &fakeTracer{}
+
+	client := &Client{
+		options:     applyOptions([]Option{WithTracer(tracer), WithTimeout(10 * time.Millisecond)}),
+		marshal:     marshal,
+		unmarshal:   unmarshal,
+		liveQueries: newLiveQueries(),
+		connCtx:     context.Background(),
+	}
+
+	type ctxKey struct{}
+
+	liveCtx := context.WithValue(context.Background(), ctxKey{}, "live")
+
+	liveChan, _ := client.liveQueries.get("known_id", true)
+	client.liveQueries.setContext("known_id", liveCtx)
+
+	result, err := marshal(liveResponse[string]{ID: []byte("known_id"), Action: "CREATE", Result: "record"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	go client.handleLiveQuery(&response{Result: result})
+
+	<-liveChan
+
+	// Nobody reads the channel, so the notification is dropped.
+	client.handleLiveQuery(&response{Result: result})
+
+	tracer.mut.Lock()
+	defer tracer.mut.Unlock()
+
+	assert.Equal(t, 2, len(tracer.notifications))
+	assert.Equal(t, "known_id", tracer.notifications[0].info.LiveID)
+	assert.Equal(t, "CREATE", tracer.notifications[0].info.Action)
+	assert.Equal(t, len(result), tracer.notifications[0].info.Size)
+	assert.Equal(t, "live", tracer.notifications[0].ctx.Value(ctxKey{}))
+	assert.NilError(t, tracer.notifications[0].err)
+	assert.Check(t, errors.Is(tracer.notifications[1].err, ErrLiveNotificationDropped))
+
+	client.liveQueries.del("known_id")
+	assert.Check(t, client.liveQueries.context("known_id") == nil)
+}
+
+//
+// -- HELPER
+//
+
+type fakeNotification struct {
+	ctx  context.Context //nolint:containedctx // recorded for assertions
+	info NotificationInfo
+	err  error
+}
+
+type fakeTracer struct {
+	mut           sync.Mutex
+	notifications []*fakeNotification
+}
+
+func (t *fakeTracer) StartRequest(ctx context.Context, _ RequestInfo) (context.Context, func(ResponseInfo)) {
+	return ctx, func(ResponseInfo) {}
+}
+
+func (t *fakeTracer) StartNotification(ctx context.Context, info NotificationInfo) func(err error) {
+	notification := &fakeNotification{ctx: ctx, info: info}
+
+	t.mut.Lock()
+	t.notifications = append(t.notifications, notification)
+	t.mut.Unlock()
+
+	return func(err error) {
+		t.mut.Lock()
+		defer t.mut.Unlock()
+
+		notification.err = err
+	}
+}
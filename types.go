@@ -1,13 +1,19 @@
 package sdbc
 
 import (
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"hash/fnv"
+	"math"
 	"math/big"
+	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/fxamacker/cbor/v2"
+	"github.com/go-surreal/sdbc/internal/surrealql"
 )
 
 //
@@ -29,10 +35,18 @@ const (
 
 var (
 	ErrTableNameRequired     = errors.New("table name is required")
+	ErrInvalidTableName      = errors.New("invalid table name")
+	ErrThingRequired         = errors.New("table or record id is required")
+	ErrFileBucketRequired    = errors.New("file bucket is required")
 	ErrDataInvalid           = errors.New("data is invalid")
 	ErrUnmarshalNotSupported = errors.New("unmarshal not supported")
 )
 
+// ID is the identifier of a record, consisting of a table and an identifier
+// part. The identifier part is either a string, an integer (int64), an array
+// ([]any), an object (map[string]any), a UUID or a Range. Use the constructors
+// (e.g. StringID or ArrayID) to create an ID and the accessors with the same
+// names to retrieve the typed identifier part.
 type ID struct {
 	// table is the name of the table in the database.
 	table string
@@ -42,10 +56,137 @@ type ID struct {
 	identifier any
 }
 
+// StringID creates a record ID with a string identifier, e.g. person:tobie.
+func StringID(table, key string) *ID {
+	return &ID{table: table, identifier: key}
+}
+
+// IntID creates a record ID with an integer identifier, e.g. person:100.
+func IntID(table string, key int64) *ID {
+	return &ID{table: table, identifier: key}
+}
+
+// ArrayID creates a record ID with an array identifier, e.g. temperature:['London', d'2024-01-01'].
+func ArrayID(table string, key ...any) *ID {
+	return &ID{table: table, identifier: normalizeIdentifier(key)}
+}
+
+// ObjectID creates a record ID with an object identifier, e.g. temperature:{ city: 'London' }.
+func ObjectID(table string, key map[string]any) *ID {
+	return &ID{table: table, identifier: normalizeIdentifier(key)}
+}
+
+// UUIDID creates a record ID with a UUID identifier, e.g. person:u'0189a3b2-...'.
+func UUIDID(table string, key UUID) *ID {
+	return &ID{table: table, identifier: key}
+}
+
+// RangeID creates a record ID range, e.g. person:1..10 or temperature:['London']..=['London', NONE].
+// It can be used to select, update or delete all records with an identifier within the range.
+func RangeID(table string, key Range) *ID {
+	return &ID{table: table, identifier: normalizeIdentifier(key)}
+}
+
 func (id *ID) recordID() {}
 
+func (id *ID) thing() {}
+
+// Table returns the table part of the record ID.
+func (id *ID) Table() Table {
+	return Table(id.table)
+}
+
+// Identifier returns the untyped identifier part of the record ID.
+func (id *ID) Identifier() any {
+	return id.identifier
+}
+
+// StringID returns the identifier part if it is a string.
+func (id *ID) StringID() (string, bool) {
+	key, ok := id.identifier.(string)
+
+	return key, ok
+}
+
+// IntID returns the identifier part if it is an integer.
+func (id *ID) IntID() (int64, bool) {
+	key, ok := id.identifier.(int64)
+
+	return key, ok
+}
+
+// ArrayID returns the identifier part if it is an array.
+func (id *ID) ArrayID() ([]any, bool) {
+	key, ok := id.identifier.([]any)
+
+	return key, ok
+}
+
+// ObjectID returns the identifier part if it is an object.
+func (id *ID) ObjectID() (map[string]any, bool) {
+	key, ok := id.identifier.(map[string]any)
+
+	return key, ok
+}
+
+// UUIDID returns the identifier part if it is a UUID.
+func (id *ID) UUIDID() (UUID, bool) {
+	key, ok := id.identifier.(UUID)
+
+	return key, ok
+}
+
+// RangeID returns the identifier part if the record ID is a range.
+func (id *ID) RangeID() (Range, bool) {
+	key, ok := id.identifier.(Range)
+
+	return key, ok
+}
+
+// String returns the record ID in the SurrealQL format, e.g. person:tobie, person:⟨tobie hitchcock⟩,
+// person:100, person:1..10 or temperature:['London', d'2024-01-01T00:00:00Z'].
+// Object keys are sorted, so the result is deterministic and can be used as a key.
 func (id *ID) String() string {
-	return fmt.Sprintf("%s%s%s", id.table, recordSeparator, id.identifier)
+	if id == nil {
+		return ""
+	}
+
+	var builder strings.Builder
+
+	builder.WriteString(escapeRecordPart(id.table))
+
+	if id.identifier == nil {
+		return builder.String()
+	}
+
+	builder.WriteString(recordSeparator)
+
+	formatIdentifier(&builder, id.identifier)
+
+	return builder.String()
+}
+
+// Key returns a deterministic representation of the record ID that can be used as a map key.
+// Two IDs are equal if and only if their keys are equal.
+func (id *ID) Key() string {
+	return id.String()
+}
+
+// Equal reports whether both record IDs point to the same record.
+func (id *ID) Equal(other *ID) bool {
+	if id == nil || other == nil {
+		return id == other
+	}
+
+	return id.Key() == other.Key()
+}
+
+// Hash returns a deterministic 64-bit hash of the record ID.
+func (id *ID) Hash() uint64 {
+	hash := fnv.New64a()
+	_, _ = hash.Write([]byte(id.Key()))
+
+	return hash.Sum64()
 }
 
 func (id *ID) MarshalCBOR() ([]byte, error) {
@@ -54,20 +195,9 @@ func (id *ID) MarshalCBOR() ([]byte, error) {
 	}
 
 	if id.identifier == nil {
-		content, err := cbor.Marshal(id.table)
-		if err != nil {
-			return nil, fmt.Errorf("failed to marshal table: %w", err)
-		}
-
-		data, err := cbor.Marshal(cbor.RawTag{
-			Number:  cborTagTable,
-			Content: content,
-		})
-		if err != nil {
-			return nil, fmt.Errorf("failed to marshal raw tag: %w", err)
-		}
+		table := Table(id.table)
 
-		return data, nil
+		return table.MarshalCBOR()
 	}
 
 	content, err := cbor.Marshal([]any{id.table, id.identifier})
@@ -86,43 +216,144 @@ func (id *ID) MarshalCBOR() ([]byte, error) {
 	return data, nil
 }
 
-func (id *ID) UnmarshalCBOR(data []byte) error {
-	var val []any
+// MarshalText returns the record ID in the SurrealQL format (see String).
+// It allows the use of record IDs in JSON documents or URL paths.
+// A nil record ID results in an empty text, like with String.
+func (id *ID) MarshalText() ([]byte, error) {
+	if id == nil {
+		return []byte{}, nil
+	}
 
-	err := cbor.Unmarshal(data, &val)
+	if id.table == "" {
+		return nil, ErrTableNameRequired
+	}
+
+	return []byte(id.String()), nil
+}
+
+// UnmarshalText parses a record ID in the SurrealQL format (see ParseID).
+func (id *ID) UnmarshalText(data []byte) error {
+	parsed, err := ParseID(string(data))
 	if err != nil {
-		return fmt.Errorf("failed to unmarshal recordID: %w", err)
+		return err
 	}
 
-	if val == nil {
-		return nil
+	*id = *parsed
+
+	return nil
+}
+
+// UnmarshalCBOR decodes a record ID either from a two-value array
+// (table and identifier) or from a string in the SurrealQL format.
+// Custom tags registered with WithTag are not applied to the identifier,
+// so tagged values within it are decoded as cbor.Tag.
+func (id *ID) UnmarshalCBOR(data []byte) error {
+	_, content, _, err := splitTag(data)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal recordID: %w", err)
 	}
 
-	if len(val) != expectedArrayLength {
-		return fmt.Errorf("%w: expected %d elements, got %d", ErrDataInvalid, expectedArrayLength, len(val))
+	var val any
+
+	if err := valueUnmarshal(content, &val); err != nil {
+		return fmt.Errorf("failed to unmarshal recordID: %w", err)
 	}
 
-	table, ok := val[0].(string)
-	if !ok {
-		return fmt.Errorf("%w: expected string, got %T", ErrDataInvalid, val[0])
+	switch typed := val.(type) {
+
+	case nil:
+		return nil
+
+	case string:
+		parsed, err := ParseID(typed)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrDataInvalid, err)
+		}
+
+		*id = *parsed
+
+		return nil
+
+	case []any:
+		if len(typed) != expectedArrayLength {
+			return fmt.Errorf("%w: expected %d elements, got %d", ErrDataInvalid, expectedArrayLength, len(typed))
+		}
+
+		table, ok := typed[0].(string)
+		if !ok {
+			return fmt.Errorf("%w: expected string, got %T", ErrDataInvalid, typed[0])
+		}
+
+		id.table = table
+		id.identifier = normalizeIdentifier(typed[1])
+
+		return nil
+
+	default:
+		return fmt.Errorf("%w: expected array or string for recordID, got %T", ErrDataInvalid, val)
 	}
+}
 
-	id.table = table
-	id.identifier = val[1]
+// normalizeIdentifier converts the given identifier into one of the
+// types documented for ID, so that typed accessors work as expected.
+func normalizeIdentifier(key any) any {
+	switch typed := key.(type) {
 
-	//switch identifier := val[1].(type) {
-	//
-	//case string:
-	//	id.identifier = StringID(identifier)
-	//
-	//case int:
-	//	id.identifier = IntID(identifier)
-	//
-	//default:
-	//	return fmt.Errorf("recordID identifier is of unsupported type %T", val[1])
-	//}
+	case int:
+		return int64(typed)
 
-	return nil
+	case int8:
+		return int64(typed)
+
+	case int16:
+		return int64(typed)
+
+	case int32:
+		return int64(typed)
+
+	case uint8:
+		return int64(typed)
+
+	case uint16:
+		return int64(typed)
+
+	case uint32:
+		return int64(typed)
+
+	case uint:
+		if typed <= math.MaxInt64 {
+			return int64(typed)
+		}
+
+	case uint64:
+		if typed <= math.MaxInt64 {
+			return int64(typed)
+		}
+
+	case []any:
+		out := make([]any, len(typed))
+		for index, item := range typed {
+			out[index] = normalizeIdentifier(item)
+		}
+
+		return out
+
+	case map[string]any:
+		out := make(map[string]any, len(typed))
+		for itemKey, item := range typed {
+			out[itemKey] = normalizeIdentifier(item)
+		}
+
+		return out
+
+	case Range:
+		typed.Begin.Value = normalizeIdentifier(typed.Begin.Value)
+		typed.End.Value = normalizeIdentifier(typed.End.Value)
+
+		return typed
+	}
+
+	return key
 }
 
 //
@@ -133,6 +364,36 @@ type RecordID interface {
 	recordID()
 }
 
+// Thing is either a Table or a record *ID and denotes
+// the target of a request. A Table targets all records
+// of the table, an *ID targets a single record and an
+// *ID created by RangeID targets all records in the range.
+type Thing interface {
+	thing()
+}
+
+// validateThing checks whether the given thing can be used for a request.
+func validateThing(thing Thing) error {
+	switch typed := thing.(type) {
+
+	case nil:
+		return ErrThingRequired
+
+	case Table:
+		return typed.Validate()
+
+	case *ID:
+		if typed == nil {
+			return ErrThingRequired
+		}
+
+		return typed.Table().Validate()
+
+	default:
+		return nil
+	}
+}
+
 type newRecordID struct {
 	table       string
 	constructor string
@@ -185,17 +446,18 @@ func NewUUID(table string) RecordID {
 func MakeID(table string, identifier any) *ID {
 	return &ID{
 		table:      table,
-		identifier: identifier,
+		identifier: normalizeIdentifier(identifier),
 	}
 }
 
+// ParseRecord parses a record ID in the SurrealQL format.
+// It reports false if the record ID is invalid.
+//
+// Deprecated: Use ParseID instead, which returns a descriptive error.
 func ParseRecord(record string) (*ID, bool) {
-	table, identifier, ok := strings.Cut(record, recordSeparator)
+	id, err := ParseID(record)
 
-	return &ID{
-		table:      table,
-		identifier: identifier,
-	}, ok
+	return id, err == nil
 }
 
 //
@@ -208,7 +470,9 @@ type DateTime struct {
 
 func (dt *DateTime) MarshalCBOR() ([]byte, error) {
 	if dt == nil {
-		data, err := cbor.Marshal(nil) // TODO: is this correct?
+		// A nil pointer is encoded as null, consistent with how the cbor
+		// library handles nil struct fields. Use Optional to send NONE.
+		data, err := cbor.Marshal(nil)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal nil: %w", err)
 		}
@@ -232,7 +496,36 @@ func (dt *DateTime) MarshalCBOR() ([]byte, error) {
 	return data, nil
 }
 
+// UnmarshalCBOR decodes a datetime from either the custom tag 12 (preferred
+// by SurrealDB), the IANA tag 0 (RFC 3339 string) or the IANA tag 1 (epoch).
+// Untagged data is treated like the content of tag 12.
 func (dt *DateTime) UnmarshalCBOR(data []byte) error {
+	tagNumber, content, tagged, err := splitTag(data)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal datetime: %w", err)
+	}
+
+	if !tagged {
+		tagNumber = cborTagDatetime
+	}
+
+	switch tagNumber {
+
+	case cborTagDatetime:
+		return dt.unmarshalCustom(content)
+
+	case cborTagDatetimeRFC3339:
+		return dt.unmarshalString(content)
+
+	case cborTagDatetimeEpoch:
+		return dt.unmarshalEpoch(content)
+
+	default:
+		return fmt.Errorf("%w: unexpected tag %d for datetime", ErrDataInvalid, tagNumber)
+	}
+}
+
+func (dt *DateTime) unmarshalCustom(data []byte) error {
 	var val []int64
 
 	if err := cbor.Unmarshal(data, &val); err != nil {
@@ -243,10 +536,6 @@ func (dt *DateTime) UnmarshalCBOR(data []byte) error {
 		return fmt.Errorf("%w: expected 1-2 elements, got %d", ErrDataInvalid, len(val))
 	}
 
-	if len(val) < 1 {
-		return fmt.Errorf("%w: expected at least one element, got none", ErrDataInvalid)
-	}
-
 	secs := val[0]
 	nano := int64(0)
 
@@ -254,11 +543,54 @@ func (dt *DateTime) UnmarshalCBOR(data []byte) error {
 		nano = val[1]
 	}
 
-	if len(val) > expectedArrayLength {
-		return fmt.Errorf("%w: expected at most %d elements, got %d", ErrDataInvalid, expectedArrayLength, len(val))
+	dt.Time = time.Unix(secs, nano)
+
+	return nil
+}
+
+func (dt *DateTime) unmarshalString(data []byte) error {
+	var val string
+
+	if err := cbor.Unmarshal(data, &val); err != nil {
+		return fmt.Errorf("failed to unmarshal datetime string: %w", err)
 	}
 
-	dt.Time = time.Unix(secs, nano)
+	parsed, err := time.Parse(time.RFC3339Nano, val)
+	if err != nil {
+		return fmt.Errorf("failed to parse datetime string: %w", err)
+	}
+
+	dt.Time = parsed
+
+	return nil
+}
+
+func (dt *DateTime) unmarshalEpoch(data []byte) error {
+	var val any
+
+	if err := cbor.Unmarshal(data, &val); err != nil {
+		return fmt.Errorf("failed to unmarshal datetime epoch: %w", err)
+	}
+
+	switch epoch := val.(type) {
+
+	case uint64:
+		if epoch > math.MaxInt64 {
+			return fmt.Errorf("%w: epoch %d out of range", ErrDataInvalid, epoch)
+		}
+
+		dt.Time = time.Unix(int64(epoch), 0)
+
+	case int64:
+		dt.Time = time.Unix(epoch, 0)
+
+	case float64:
+		secs, frac := math.Modf(epoch)
+		dt.Time = time.Unix(int64(secs), int64(frac*nanosecond))
+
+	default:
+		return fmt.Errorf("%w: expected number for epoch, got %T", ErrDataInvalid, val)
+	}
 
 	return nil
 }
@@ -273,7 +605,9 @@ type Duration struct {
 
 func (d *Duration) MarshalCBOR() ([]byte, error) {
 	if d == nil {
-		data, err := cbor.Marshal(nil) // TODO: is this correct?
+		// A nil pointer is encoded as null, consistent with how the cbor
+		// library handles nil struct fields. Use Optional to send NONE.
+		data, err := cbor.Marshal(nil)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal nil: %w", err)
 		}
@@ -301,13 +635,43 @@ func (d *Duration) MarshalCBOR() ([]byte, error) {
 	return data, nil
 }
 
+// UnmarshalCBOR decodes a duration from either the custom tag 14 (preferred
+// by SurrealDB) or the custom tag 13 (SurrealQL string representation).
+// Untagged data is treated like the content of tag 14.
 func (d *Duration) UnmarshalCBOR(data []byte) error {
+	tagNumber, content, tagged, err := splitTag(data)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal duration: %w", err)
+	}
+
+	if !tagged {
+		tagNumber = cborTagDuration
+	}
+
+	switch tagNumber {
+
+	case cborTagDuration:
+		return d.unmarshalCustom(content)
+
+	case cborTagDurationString:
+		return d.unmarshalString(content)
+
+	default:
+		return fmt.Errorf("%w: unexpected tag %d for duration", ErrDataInvalid, tagNumber)
+	}
+}
+
+func (d *Duration) unmarshalCustom(data []byte) error {
 	var val []int64
 
 	if err := cbor.Unmarshal(data, &val); err != nil {
 		return fmt.Errorf("failed to unmarshal duration: %w", err)
 	}
 
+	if len(val) > expectedArrayLength {
+		return fmt.Errorf("%w: expected at most %d elements, got %d", ErrDataInvalid, expectedArrayLength, len(val))
+	}
+
 	var dur time.Duration
 
 	if len(val) > 0 {
@@ -318,8 +682,21 @@ func (d *Duration) UnmarshalCBOR(data []byte) error {
 		dur += time.Duration(val[1])
 	}
 
-	if len(val) > expectedArrayLength {
-		return fmt.Errorf("%w: expected at most %d elements, got %d", ErrDataInvalid, expectedArrayLength, len(val))
+	d.Duration = dur
+
+	return nil
+}
+
+func (d *Duration) unmarshalString(data []byte) error {
+	var val string
+
+	if err := cbor.Unmarshal(data, &val); err != nil {
+		return fmt.Errorf("failed to unmarshal duration string: %w", err)
+	}
+
+	dur, err := parseDuration(val)
+	if err != nil {
+		return err
 	}
 
 	d.Duration = dur
@@ -327,6 +704,57 @@ func (d *Duration) UnmarshalCBOR(data []byte) error {
 	return nil
 }
 
+// parseDuration parses a duration in the SurrealQL string format.
+// In contrast to time.ParseDuration, it supports days, weeks and years.
+func parseDuration(str string) (time.Duration, error) {
+	if str == "" {
+		return 0, fmt.Errorf("%w: empty duration string", ErrDataInvalid)
+	}
+
+	var dur time.Duration
+
+	for rest := str; rest != ""; {
+		digits := 0
+		for digits < len(rest) && rest[digits] >= '0' && rest[digits] <= '9' {
+			digits++
+		}
+
+		if digits == 0 {
+			return 0, fmt.Errorf("%w: invalid duration %q", ErrDataInvalid, str)
+		}
+
+		num, err := strconv.ParseInt(rest[:digits], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("%w: invalid duration %q: %w", ErrDataInvalid, str, err)
+		}
+
+		rest = rest[digits:]
+
+		var unit time.Duration
+
+		for _, candidate := range surrealql.DurationUnits {
+			if strings.HasPrefix(rest, candidate.Name) {
+				unit = candidate.Value
+				rest = rest[len(candidate.Name):]
+
+				break
+			}
+		}
+
+		if unit == 0 {
+			return 0, fmt.Errorf("%w: invalid unit in duration %q", ErrDataInvalid, str)
+		}
+
+		if num > math.MaxInt64/int64(unit) || time.Duration(num)*unit > math.MaxInt64-dur {
+			return 0, fmt.Errorf("%w: duration %q out of range", ErrDataInvalid, str)
+		}
+
+		dur += time.Duration(num) * unit
+	}
+
+	return dur, nil
+}
+
 //
 // -- DECIMAL
 //
@@ -337,7 +765,9 @@ type Decimal struct {
 
 func (d *Decimal) MarshalCBOR() ([]byte, error) {
 	if d == nil {
-		data, err := cbor.Marshal(nil) // TODO: is this correct?
+		// A nil pointer is encoded as null, consistent with how the cbor
+		// library handles nil struct fields. Use Optional to send NONE.
+		data, err := cbor.Marshal(nil)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal nil: %w", err)
 		}
@@ -361,6 +791,291 @@ func (d *Decimal) MarshalCBOR() ([]byte, error) {
 	return data, nil
 }
 
+func (d *Decimal) UnmarshalCBOR(data []byte) error {
+	var val any
+
+	if err := unmarshalTagged(data, cborTagDecimal, &val); err != nil {
+		return fmt.Errorf("failed to unmarshal decimal: %w", err)
+	}
+
+	switch num := val.(type) {
+
+	case string:
+		parsed, err := strconv.ParseFloat(num, 64)
+		if err != nil {
+			return fmt.Errorf("failed to parse decimal: %w", err)
+		}
+
+		d.float64 = parsed
+
+	case float64:
+		d.float64 = num
+
+	case int64:
+		d.float64 = float64(num)
+
+	case uint64:
+		d.float64 = float64(num)
+
+	default:
+		return fmt.Errorf("%w: expected string or number for decimal, got %T", ErrDataInvalid, val)
+	}
+
+	return nil
+}
+
+// MakeDecimal creates a new decimal from the given value.
+func MakeDecimal(val float64) Decimal {
+	return Decimal{float64: val}
+}
+
+// Float64 returns the value of the decimal.
+func (d Decimal) Float64() float64 {
+	return d.float64
+}
+
+//
+// -- TABLE
+//
+
+// Table is the name of a table in the database.
+// It is encoded with its own tag, so it cannot be confused with a record ID.
+type Table string
+
+func (t Table) thing() {}
+
+// Validate checks whether the table name can be used for a request.
+func (t Table) Validate() error {
+	if t == "" {
+		return ErrTableNameRequired
+	}
+
+	if !utf8.ValidString(string(t)) || strings.ContainsRune(string(t), 0) {
+		return fmt.Errorf("%w: %q", ErrInvalidTableName, string(t))
+	}
+
+	return nil
+}
+
+// String returns the table name as a SurrealQL identifier.
+// Names that are not plain identifiers are escaped with backticks.
+func (t Table) String() string {
+	return surrealql.EscapeIdent(string(t))
+}
+
+func (t *Table) MarshalCBOR() ([]byte, error) {
+	if err := t.Validate(); err != nil {
+		return nil, err
+	}
+
+	return marshalTagged(cborTagTable, string(*t))
+}
+
+func (t *Table) UnmarshalCBOR(data []byte) error {
+	var name string
+
+	if err := unmarshalTagged(data, cborTagTable, &name); err != nil {
+		return fmt.Errorf("failed to unmarshal table: %w", err)
+	}
+
+	*t = Table(name)
+
+	return nil
+}
+
+//
+// -- UUID
+//
+
+const (
+	uuidLength       = 16
+	uuidStringLength = 36
+)
+
+var uuidDashPositions = []int{8, 13, 18, 23}
+
+// UUID is a universally unique identifier as defined by RFC 9562.
+type UUID [uuidLength]byte
+
+// ParseUUID parses a UUID in the canonical
+// string format (xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx).
+func ParseUUID(str string) (UUID, error) {
+	var uuid UUID
+
+	if len(str) != uuidStringLength {
+		return uuid, fmt.Errorf("%w: invalid uuid length %d", ErrDataInvalid, len(str))
+	}
+
+	for _, pos := range uuidDashPositions {
+		if str[pos] != '-' {
+			return uuid, fmt.Errorf("%w: invalid uuid format %q", ErrDataInvalid, str)
+		}
+	}
+
+	if _, err := hex.Decode(uuid[:], []byte(strings.ReplaceAll(str, "-", ""))); err != nil {
+		return uuid, fmt.Errorf("%w: invalid uuid %q: %w", ErrDataInvalid, str, err)
+	}
+
+	return uuid, nil
+}
+
+func (u UUID) String() string {
+	var buf [uuidStringLength]byte
+
+	hex.Encode(buf[0:8], u[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], u[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], u[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], u[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], u[10:])
+
+	return string(buf[:])
+}
+
+func (u *UUID) MarshalCBOR() ([]byte, error) {
+	return marshalTagged(CBORTagUUID, u[:])
+}
+
+// UnmarshalCBOR decodes a UUID from either the IANA tag 37 (binary, preferred
+// by SurrealDB) or the custom tag 9 (string representation).
+func (u *UUID) UnmarshalCBOR(data []byte) error {
+	tagNumber, content, tagged, err := splitTag(data)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal uuid: %w", err)
+	}
+
+	if tagged && tagNumber != CBORTagUUID && tagNumber != cborTagUUIDString {
+		return fmt.Errorf("%w: expected tag %d or %d, got %d", ErrDataInvalid, CBORTagUUID, cborTagUUIDString, tagNumber)
+	}
+
+	var val any
+
+	if err := cbor.Unmarshal(content, &val); err != nil {
+		return fmt.Errorf("failed to unmarshal uuid: %w", err)
+	}
+
+	switch uuid := val.(type) {
+
+	case []byte:
+		if len(uuid) != uuidLength {
+			return fmt.Errorf("%w: expected %d bytes for uuid, got %d", ErrDataInvalid, uuidLength, len(uuid))
+		}
+
+		*u = UUID(uuid)
+
+	case string:
+		parsed, err := ParseUUID(uuid)
+		if err != nil {
+			return err
+		}
+
+		*u = parsed
+
+	default:
+		return fmt.Errorf("%w: unexpected value %T for uuid (tag %d)", ErrDataInvalid, val, tagNumber)
+	}
+
+	return nil
+}
+
+//
+// -- NONE
+//
+
+// None represents the NONE value of SurrealDB, which
+// denotes the absence of a value (in contrast to null).
+type None struct{}
+
+func (n *None) MarshalCBOR() ([]byte, error) {
+	return marshalNone()
+}
+
+func (n *None) UnmarshalCBOR(data []byte) error {
+	tagNumber, _, tagged, err := splitTag(data)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal none: %w", err)
+	}
+
+	if !tagged || tagNumber != CBORTagNone {
+		return fmt.Errorf("%w: expected tag %d for none", ErrDataInvalid, CBORTagNone)
+	}
+
+	return nil
+}
+
+//
+// -- FILE
+//
+
+// File is a reference to a file stored in a bucket of SurrealDB.
+// In SurrealQL, it is written as f"bucket:/some/key.txt".
+type File struct {
+	Bucket string
+	Key    string
+}
+
+// String returns the file reference in the SurrealQL format.
+func (f File) String() string {
+	key := f.Key
+	if !strings.HasPrefix(key, "/") {
+		key = "/" + key
+	}
+
+	return "f" + quoteString(f.Bucket+":"+key, '"')
+}
+
+func (f *File) MarshalCBOR() ([]byte, error) {
+	if f.Bucket == "" {
+		return nil, ErrFileBucketRequired
+	}
+
+	return marshalTagged(cborTagFile, []string{f.Bucket, f.Key})
+}
+
+func (f *File) UnmarshalCBOR(data []byte) error {
+	var val []string
+
+	if err := unmarshalTagged(data, cborTagFile, &val); err != nil {
+		return fmt.Errorf("failed to unmarshal file: %w", err)
+	}
+
+	if len(val) != expectedArrayLength {
+		return fmt.Errorf("%w: expected %d elements for file, got %d", ErrDataInvalid, expectedArrayLength, len(val))
+	}
+
+	f.Bucket = val[0]
+	f.Key = val[1]
+
+	return nil
+}
+
+//
+// -- FUTURE
+//
+
+// Future is a value that is computed each time it is selected.
+// It holds the SurrealQL block of the future, e.g. "{ time::now() }".
+type Future string
+
+func (f *Future) MarshalCBOR() ([]byte, error) {
+	return marshalTagged(cborTagFuture, string(*f))
+}
+
+func (f *Future) UnmarshalCBOR(data []byte) error {
+	var block string
+
+	if err := unmarshalTagged(data, cborTagFuture, &block); err != nil {
+		return fmt.Errorf("failed to unmarshal future: %w", err)
+	}
+
+	*f = Future(block)
+
+	return nil
+}
+
 //
 // -- BIG INT
 //
@@ -1,6 +1,8 @@
 package sdbc
 
 import (
+	"errors"
+	"reflect"
 	"testing"
 	"time"
 
@@ -50,3 +52,568 @@ func TestDurationErrorCases(t *testing.T) {
 
 	assert.ErrorContains(t, err, "could not parse duration")
 }
+
+func TestDateTimeAlternateEncodings(t *testing.T) {
+	t.Parallel()
+
+	expected := time.Date(2024, 1, 2, 3, 4, 5, 500_000_000, time.UTC)
+
+	tests := []struct {
+		name string
+		data any
+	}{
+		{
+			name: "custom tag 12",
+			data: cbor.Tag{Number: cborTagDatetime, Content: []int64{expected.Unix(), int64(expected.Nanosecond())}},
+		},
+		{
+			name: "iana tag 0",
+			data: cbor.Tag{Number: cborTagDatetimeRFC3339, Content: expected.Format(time.RFC3339Nano)},
+		},
+		{
+			name: "iana tag 1 float",
+			data: cbor.Tag{Number: cborTagDatetimeEpoch, Content: float64(expected.Unix()) + 0.5},
+		},
+		{
+			name: "untagged",
+			data: []int64{expected.Unix(), int64(expected.Nanosecond())},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			data, err := cbor.Marshal(test.data)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var out DateTime
+
+			if err := cbor.Unmarshal(data, &out); err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Check(t, expected.Equal(out.Time), "got %s", out.Time)
+		})
+	}
+
+	data, err := cbor.Marshal(cbor.Tag{Number: cborTagDatetimeEpoch, Content: expected.Unix()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out DateTime
+
+	if err := cbor.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Check(t, expected.Truncate(time.Second).Equal(out.Time))
+}
+
+func TestDurationAlternateEncodings(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in       string
+		expected time.Duration
+	}{
+		{in: "0ns", expected: 0},
+		{in: "1h30m", expected: time.Hour + 30*time.Minute},
+		{in: "1y2w3d", expected: (365 + 14 + 3) * 24 * time.Hour},
+		{in: "5ms7us9ns", expected: 5*time.Millisecond + 7*time.Microsecond + 9},
+		{in: "1m1s", expected: time.Minute + time.Second},
+	}
+
+	for _, test := range tests {
+		data, err := cbor.Marshal(cbor.Tag{Number: cborTagDurationString, Content: test.in})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var out Duration
+
+		if err := cbor.Unmarshal(data, &out); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, test.expected, out.Duration, test.in)
+	}
+
+	for _, invalid := range []string{"", "1", "h", "1x", "99999999999y", "200y200y"} {
+		data, err := cbor.Marshal(cbor.Tag{Number: cborTagDurationString, Content: invalid})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var out Duration
+
+		assert.Check(t, cbor.Unmarshal(data, &out) != nil, invalid)
+	}
+}
+
+func TestUnmarshalAnyDateTimeDuration(t *testing.T) {
+	t.Parallel()
+
+	marshal, unmarshal := newTestCodec(t, nil)
+
+	now := time.Unix(time.Now().Unix(), 0)
+
+	data, err := marshal(map[string]any{
+		"rfc3339":  cbor.Tag{Number: cborTagDatetimeRFC3339, Content: now.Format(time.RFC3339)},
+		"epoch":    cbor.Tag{Number: cborTagDatetimeEpoch, Content: now.Unix()},
+		"custom":   cbor.Tag{Number: cborTagDatetime, Content: []int64{now.Unix()}},
+		"string":   cbor.Tag{Number: cborTagDurationString, Content: "1d"},
+		"duration": cbor.Tag{Number: cborTagDuration, Content: []int64{60}},
+		"nested":   []any{cbor.Tag{Number: cborTagDatetimeEpoch, Content: now.Unix()}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out map[string]any
+
+	if err := unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, key := range []string{"rfc3339", "epoch", "custom"} {
+		val, ok := out[key].(DateTime)
+		assert.Check(t, ok, "%s: got %T", key, out[key])
+		assert.Check(t, now.Equal(val.Time), key)
+	}
+
+	assert.DeepEqual(t, Duration{24 * time.Hour}, out["string"])
+	assert.DeepEqual(t, Duration{time.Minute}, out["duration"])
+
+	nested, ok := out["nested"].([]any)
+	assert.Assert(t, ok)
+
+	_, ok = nested[0].(DateTime)
+	assert.Check(t, ok, "got %T", nested[0])
+}
+
+func TestUnmarshalAnyTags(t *testing.T) {
+	t.Parallel()
+
+	marshal, unmarshal := newTestCodec(t, nil)
+
+	uuid, err := ParseUUID("0189a3b2-5c1e-7d6f-8a9b-0c1d2e3f4a5b")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	point := GeometryPoint{Longitude: 1.5, Latitude: 2.5}
+
+	data, err := marshal(map[string]any{
+		"none":       &None{},
+		"table":      cbor.Tag{Number: cborTagTable, Content: "person"},
+		"record":     cbor.Tag{Number: cborTagRecordID, Content: []any{"person", "tobie"}},
+		"uuid":       &uuid,
+		"uuidString": cbor.Tag{Number: cborTagUUIDString, Content: uuid.String()},
+		"decimal":    cbor.Tag{Number: cborTagDecimal, Content: "1.25"},
+		"point":      &point,
+		"line":       &GeometryLine{point, point},
+		"collection": &GeometryCollection{point, GeometryLine{point, point}},
+		"file":       &File{Bucket: "avatars", Key: "/tobie.png"},
+		"future":     cbor.Tag{Number: cborTagFuture, Content: "{ time::now() }"},
+		"range":      &Range{Begin: Included(1), End: Excluded("z")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out map[string]any
+
+	if err := unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.DeepEqual(t, None{}, out["none"])
+	assert.DeepEqual(t, Table("person"), out["table"])
+	assert.DeepEqual(t, uuid, out["uuid"])
+	assert.DeepEqual(t, uuid, out["uuidString"])
+	assert.DeepEqual(t, MakeDecimal(1.25).Float64(), out["decimal"].(Decimal).Float64())
+	assert.DeepEqual(t, point, out["point"])
+	assert.DeepEqual(t, GeometryLine{point, point}, out["line"])
+	assert.DeepEqual(t, GeometryCollection{point, GeometryLine{point, point}}, out["collection"])
+	assert.DeepEqual(t, File{Bucket: "avatars", Key: "/tobie.png"}, out["file"])
+	assert.DeepEqual(t, Future("{ time::now() }"), out["future"])
+	assert.DeepEqual(t, Range{Begin: Included(uint64(1)), End: Excluded("z")}, out["range"])
+
+	record, ok := out["record"].(*ID)
+	assert.Assert(t, ok, "got %T", out["record"])
+	assert.Equal(t, "person:tobie", record.String())
+}
+
+func TestUnmarshalAnyCustomTag(t *testing.T) {
+	t.Parallel()
+
+	marshal, unmarshal := newTestCodec(t, []decodeTag{
+		{number: 1000, typ: reflect.TypeFor[customTagged](), custom: true},
+		{number: cborTagTable, typ: reflect.TypeFor[customTable](), custom: true},
+	})
+
+	data, err := marshal([]any{customTagged{Value: "some"}, customTable("person")})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var raw []cbor.RawTag
+
+	if err := cbor.Unmarshal(data, &raw); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, uint64(1000), raw[0].Number)
+	assert.Equal(t, uint64(cborTagTable), raw[1].Number)
+
+	var out []any
+
+	if err := unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.DeepEqual(t, []any{customTagged{Value: "some"}, customTable("person")}, out)
+}
+
+func TestUUID(t *testing.T) {
+	t.Parallel()
+
+	str := "0189a3b2-5c1e-7d6f-8a9b-0c1d2e3f4a5b"
+
+	uuid, err := ParseUUID(str)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, str, uuid.String())
+
+	data, err := cbor.Marshal(&uuid)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out UUID
+
+	if err := cbor.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, uuid, out)
+
+	wrongTag, err := cbor.Marshal(cbor.Tag{Number: cborTagTable, Content: uuid[:]})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Check(t, errors.Is(cbor.Unmarshal(wrongTag, &out), ErrDataInvalid))
+
+	for _, invalid := range []string{"", "0189a3b2-5c1e-7d6f-8a9b-0c1d2e3f4a5", "0189a3b2+5c1e-7d6f-8a9b-0c1d2e3f4a5b", "x189a3b2-5c1e-7d6f-8a9b-0c1d2e3f4a5b"} {
+		_, err := ParseUUID(invalid)
+		assert.Check(t, errors.Is(err, ErrDataInvalid), invalid)
+	}
+}
+
+func TestTable(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "person", Table("person").String())
+	assert.Equal(t, "person_2", Table("person_2").String())
+	assert.Equal(t, "`123`", Table("123").String())
+	assert.Equal(t, "`some table`", Table("some table").String())
+	assert.Equal(t, "`some\\`table`", Table("some`table").String())
+
+	assert.Check(t, errors.Is(Table("").Validate(), ErrTableNameRequired))
+	assert.Check(t, errors.Is(Table("a\x00b").Validate(), ErrInvalidTableName))
+	assert.Check(t, errors.Is(Table("\xff").Validate(), ErrInvalidTableName))
+
+	table := Table("person")
+
+	data, err := cbor.Marshal(&table)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var raw cbor.RawTag
+
+	if err := cbor.Unmarshal(data, &raw); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, uint64(cborTagTable), raw.Number)
+
+	var out Table
+
+	if err := cbor.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, table, out)
+
+	empty := Table("")
+
+	_, err = cbor.Marshal(&empty)
+	assert.Check(t, errors.Is(err, ErrTableNameRequired))
+}
+
+func TestFile(t *testing.T) {
+	t.Parallel()
+
+	file := File{Bucket: "avatars", Key: "/users/tobie.png"}
+
+	assert.Equal(t, `f"avatars:/users/tobie.png"`, file.String())
+	assert.Equal(t, `f"avatars:/tobie.png"`, File{Bucket: "avatars", Key: "tobie.png"}.String())
+
+	data, err := cbor.Marshal(&file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var raw cbor.RawTag
+
+	if err := cbor.Unmarshal(data, &raw); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, uint64(cborTagFile), raw.Number)
+
+	var out File
+
+	if err := cbor.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, file, out)
+
+	_, err = cbor.Marshal(&File{Key: "/tobie.png"})
+	assert.Check(t, errors.Is(err, ErrFileBucketRequired))
+
+	invalid, err := cbor.Marshal(cbor.Tag{Number: cborTagFile, Content: []string{"avatars"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Check(t, errors.Is(cbor.Unmarshal(invalid, &out), ErrDataInvalid))
+}
+
+func TestFuture(t *testing.T) {
+	t.Parallel()
+
+	future := Future("{ time::now() }")
+
+	data, err := cbor.Marshal(&future)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out Future
+
+	if err := cbor.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, future, out)
+}
+
+func TestIDString(t *testing.T) {
+	t.Parallel()
+
+	date := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	uuid, err := ParseUUID("0189a3b2-5c1e-7d6f-8a9b-0c1d2e3f4a5b")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		id       *ID
+		expected string
+	}{
+		{id: StringID("person", "tobie"), expected: "person:tobie"},
+		{id: StringID("person", "tobie hitchcock"), expected: "person:⟨tobie hitchcock⟩"},
+		{id: StringID("person", "100"), expected: "person:⟨100⟩"},
+		{id: StringID("person", "a⟩b"), expected: `person:⟨a\⟩b⟩`},
+		{id: StringID("some table", "x"), expected: "⟨some table⟩:x"},
+		{id: IntID("person", 100), expected: "person:100"},
+		{id: MakeID("person", 100), expected: "person:100"},
+		{id: UUIDID("person", uuid), expected: "person:u'0189a3b2-5c1e-7d6f-8a9b-0c1d2e3f4a5b'"},
+		{
+			id:       ArrayID("temperature", "London", DateTime{date}),
+			expected: "temperature:['London', d'2024-01-01T00:00:00Z']",
+		},
+		{
+			id:       ArrayID("t", 1, 2.5, "it's", nil, true, []any{Duration{90 * time.Minute}}),
+			expected: `t:[1, 2.5f, 'it\'s', NULL, true, [1h30m]]`,
+		},
+		{
+			id:       ObjectID("t", map[string]any{"b": 1, "a": "x", "c d": StringID("u", "v")}),
+			expected: `t:{ a: 'x', b: 1, "c d": u:v }`,
+		},
+		{id: MakeID("person", nil), expected: "person"},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, test.id.String())
+	}
+}
+
+func TestIDAccessors(t *testing.T) {
+	t.Parallel()
+
+	key, ok := StringID("person", "tobie").StringID()
+	assert.Check(t, ok)
+	assert.Equal(t, "tobie", key)
+
+	_, ok = StringID("person", "tobie").IntID()
+	assert.Check(t, !ok)
+
+	num, ok := MakeID("person", uint32(7)).IntID()
+	assert.Check(t, ok)
+	assert.Equal(t, int64(7), num)
+
+	arr, ok := ArrayID("t", "a", 1).ArrayID()
+	assert.Check(t, ok)
+	assert.DeepEqual(t, []any{"a", int64(1)}, arr)
+
+	obj, ok := ObjectID("t", map[string]any{"a": 1}).ObjectID()
+	assert.Check(t, ok)
+	assert.DeepEqual(t, map[string]any{"a": int64(1)}, obj)
+
+	assert.Equal(t, Table("t"), ArrayID("t").Table())
+}
+
+func TestIDRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	marshal, unmarshal := newTestCodec(t, nil)
+
+	date := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	uuid, err := ParseUUID("0189a3b2-5c1e-7d6f-8a9b-0c1d2e3f4a5b")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ids := []*ID{
+		StringID("person", "tobie"),
+		IntID("person", 100),
+		IntID("person", -100),
+		UUIDID("person", uuid),
+		ArrayID("temperature", "London", DateTime{date}),
+		ObjectID("temperature", map[string]any{"city": "London", "at": DateTime{date}, "ref": IntID("u", 1)}),
+	}
+
+	for _, id := range ids {
+		data, err := marshal(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var out *ID
+
+		if err := unmarshal(data, &out); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Check(t, id.Equal(out), "%s != %s", id, out)
+		assert.Equal(t, id.Hash(), out.Hash())
+	}
+}
+
+func TestIDEqual(t *testing.T) {
+	t.Parallel()
+
+	a := ObjectID("t", map[string]any{"a": 1, "b": []any{"x", 2}})
+	b := ObjectID("t", map[string]any{"b": []any{"x", int64(2)}, "a": int64(1)})
+
+	assert.Check(t, a.Equal(b))
+	assert.Equal(t, a.Key(), b.Key())
+	assert.Equal(t, a.Hash(), b.Hash())
+
+	assert.Check(t, !a.Equal(ObjectID("t", map[string]any{"a": 2})))
+	assert.Check(t, !StringID("t", "1").Equal(IntID("t", 1)))
+	assert.Check(t, !a.Equal(nil))
+
+	keys := map[string]bool{a.Key(): true}
+	assert.Check(t, keys[b.Key()])
+}
+
+//
+// -- HELPER
+//
+
+func newTestCodec(t *testing.T, tags []decodeTag) (Marshal, Unmarshal) {
+	t.Helper()
+
+	encTags, decTags, err := newTagSets(tags)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	enc, err := cbor.EncOptions{}.EncModeWithTags(encTags)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dec, err := cbor.DecOptions{}.DecModeWithTags(decTags)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return enc.Marshal, newUnmarshal(dec)
+}
+
+type customTagged struct {
+	Value string `cbor:"value"`
+}
+
+type customTable string
+
+func TestRangeID(t *testing.T) {
+	t.Parallel()
+
+	marshal, unmarshal := newTestCodec(t, nil)
+
+	id := RangeID("person", Range{Begin: Included(1), End: Included([]any{"z", 2})})
+
+	assert.Equal(t, "person:1..=['z', 2]", id.String())
+
+	rng, ok := id.RangeID()
+	assert.Check(t, ok)
+	assert.DeepEqual(t, Included(int64(1)), rng.Begin)
+
+	data, err := marshal(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out any
+
+	if err := unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+
+	decoded, ok := out.(*ID)
+	assert.Assert(t, ok, "got %T", out)
+	assert.Check(t, id.Equal(decoded), "%s != %s", id, decoded)
+
+	_, ok = decoded.RangeID()
+	assert.Check(t, ok)
+}
+
+func TestValidateThing(t *testing.T) {
+	t.Parallel()
+
+	var nilID *ID
+
+	assert.Check(t, errors.Is(validateThing(nil), ErrThingRequired))
+	assert.Check(t, errors.Is(validateThing(nilID), ErrThingRequired))
+	assert.Check(t, errors.Is(validateThing(Table("")), ErrTableNameRequired))
+	assert.Check(t, errors.Is(validateThing(StringID("", "x")), ErrTableNameRequired))
+	assert.NilError(t, validateThing(Table("person")))
+	assert.NilError(t, validateThing(StringID("person", "tobie")))
+	assert.NilError(t, validateThing(RangeID("person", Range{})))
+}
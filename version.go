@@ -0,0 +1,182 @@
+package sdbc
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const semVerParts = 3 // major, minor and patch
+
+var ErrInvalidVersion = errors.New("invalid version")
+
+// version2 is the version of SurrealDB that introduced
+// several RPC methods like upsert, relate or graphql.
+var version2 = SemVer{Major: 2}
+
+// version21 is the version of SurrealDB that introduced
+// the configuration of exports (e.g. the included tables).
+var version21 = SemVer{Major: 2, Minor: 1}
+
+// version22 is the version of SurrealDB that introduced the
+// extended params of the CRUD methods (see CRUDOptions).
+var version22 = SemVer{Major: 2, Minor: 2}
+
+// SemVer is a semantic version as defined by https://semver.org.
+type SemVer struct {
+	Major int
+	Minor int
+	Patch int
+
+	// PreRelease is the optional pre-release part, e.g. "beta.1".
+	PreRelease string
+
+	// Build is the optional build metadata, e.g. "20240101".
+	// It is ignored when comparing versions.
+	Build string
+}
+
+// ParseSemVer parses a semantic version like 2.1.0 or 2.0.0-beta.1+build.
+// A leading "v" or "surrealdb-" prefix is accepted as well.
+func ParseSemVer(str string) (SemVer, error) {
+	var version SemVer
+
+	rest := strings.TrimPrefix(strings.TrimPrefix(strings.TrimSpace(str), versionPrefix), "v")
+
+	if before, after, found := strings.Cut(rest, "+"); found {
+		rest, version.Build = before, after
+
+		if version.Build == "" {
+			return SemVer{}, fmt.Errorf("%w: empty build metadata in %q", ErrInvalidVersion, str)
+		}
+	}
+
+	if before, after, found := strings.Cut(rest, "-"); found {
+		rest, version.PreRelease = before, after
+
+		if version.PreRelease == "" {
+			return SemVer{}, fmt.Errorf("%w: empty pre-release in %q", ErrInvalidVersion, str)
+		}
+	}
+
+	parts := strings.Split(rest, ".")
+	if len(parts) != semVerParts {
+		return SemVer{}, fmt.Errorf("%w: expected major.minor.patch, got %q", ErrInvalidVersion, str)
+	}
+
+	numbers := make([]int, len(parts))
+
+	for index, part := range parts {
+		number, err := strconv.Atoi(part)
+		if err != nil || number < 0 {
+			return SemVer{}, fmt.Errorf("%w: invalid number %q in %q", ErrInvalidVersion, part, str)
+		}
+
+		numbers[index] = number
+	}
+
+	version.Major, version.Minor, version.Patch = numbers[0], numbers[1], numbers[2]
+
+	return version, nil
+}
+
+// String returns the version in the format major.minor.patch[-pre-release][+build].
+func (v SemVer) String() string {
+	str := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+
+	if v.PreRelease != "" {
+		str += "-" + v.PreRelease
+	}
+
+	if v.Build != "" {
+		str += "+" + v.Build
+	}
+
+	return str
+}
+
+// IsZero reports whether the version is unknown (0.0.0).
+func (v SemVer) IsZero() bool {
+	return v == SemVer{}
+}
+
+// Compare returns -1, 0 or +1 depending on whether v is lower than,
+// equal to or greater than other, following the semver precedence rules.
+func (v SemVer) Compare(other SemVer) int {
+	for _, diff := range []int{v.Major - other.Major, v.Minor - other.Minor, v.Patch - other.Patch} {
+		if diff != 0 {
+			return sign(diff)
+		}
+	}
+
+	return comparePreRelease(v.PreRelease, other.PreRelease)
+}
+
+// AtLeast reports whether v is equal to or greater than other.
+func (v SemVer) AtLeast(other SemVer) bool {
+	return v.Compare(other) >= 0
+}
+
+// comparePreRelease compares two pre-release parts. A version without
+// a pre-release part has a higher precedence than one with it.
+func comparePreRelease(left, right string) int {
+	switch {
+
+	case left == right:
+		return 0
+
+	case left == "":
+		return 1
+
+	case right == "":
+		return -1
+	}
+
+	leftParts := strings.Split(left, ".")
+	rightParts := strings.Split(right, ".")
+
+	for index := range min(len(leftParts), len(rightParts)) {
+		if res := comparePreReleasePart(leftParts[index], rightParts[index]); res != 0 {
+			return res
+		}
+	}
+
+	return sign(len(leftParts) - len(rightParts))
+}
+
+// comparePreReleasePart compares numeric identifiers numerically and
+// others lexically. Numeric identifiers have a lower precedence.
+func comparePreReleasePart(left, right string) int {
+	leftNum, leftErr := strconv.Atoi(left)
+	rightNum, rightErr := strconv.Atoi(right)
+
+	switch {
+
+	case leftErr == nil && rightErr == nil:
+		return sign(leftNum - rightNum)
+
+	case leftErr == nil:
+		return -1
+
+	case rightErr == nil:
+		return 1
+
+	default:
+		return strings.Compare(left, right)
+	}
+}
+
+func sign(val int) int {
+	switch {
+
+	case val < 0:
+		return -1
+
+	case val > 0:
+		return 1
+
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,88 @@
+package sdbc
+
+import (
+	"errors"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestParseSemVer(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in   string
+		want SemVer
+	}{
+		{in: "2.3.8", want: SemVer{Major: 2, Minor: 3, Patch: 8}},
+		{in: "v1.0.0", want: SemVer{Major: 1}},
+		{in: "surrealdb-2.0.0", want: SemVer{Major: 2}},
+		{in: "2.0.0-beta.1", want: SemVer{Major: 2, PreRelease: "beta.1"}},
+		{in: "2.1.0-nightly+20240101", want: SemVer{Major: 2, Minor: 1, PreRelease: "nightly", Build: "20240101"}},
+		{in: "3.0.0+abc-def", want: SemVer{Major: 3, Build: "abc-def"}},
+	}
+
+	for _, test := range tests {
+		got, err := ParseSemVer(test.in)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, test.want, got)
+		assert.Equal(t, test.want.String(), got.String())
+	}
+
+	for _, invalid := range []string{"", "2", "2.0", "2.0.0.0", "a.b.c", "2.0.-1", "2.0.0-", "2.0.0+"} {
+		_, err := ParseSemVer(invalid)
+		assert.Check(t, errors.Is(err, ErrInvalidVersion), invalid)
+	}
+}
+
+func TestSemVerCompare(t *testing.T) {
+	t.Parallel()
+
+	// ordered by precedence as defined by https://semver.org
+	ordered := []string{
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"1.0.1",
+		"1.2.0",
+		"2.0.0",
+	}
+
+	versions := make([]SemVer, len(ordered))
+
+	for index, str := range ordered {
+		version, err := ParseSemVer(str)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		versions[index] = version
+	}
+
+	for i := range versions {
+		for j := range versions {
+			want := 0
+
+			switch {
+			case i < j:
+				want = -1
+			case i > j:
+				want = 1
+			}
+
+			assert.Equal(t, want, versions[i].Compare(versions[j]), "%s <=> %s", versions[i], versions[j])
+		}
+	}
+
+	assert.Check(t, SemVer{Major: 2, Build: "a"}.Compare(SemVer{Major: 2, Build: "b"}) == 0)
+	assert.Check(t, SemVer{Major: 2, Minor: 1}.AtLeast(SemVer{Major: 2}))
+	assert.Check(t, !SemVer{Major: 1, Minor: 9}.AtLeast(SemVer{Major: 2}))
+}